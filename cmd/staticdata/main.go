@@ -2,23 +2,140 @@ package main
 
 import (
 	"context"
+	"expvar"
+	"flag"
+	"fmt"
 	"log"
+	"log/slog"
 	"net/http"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/niklod/json-to-graphql-go/pkg/api"
+	"github.com/niklod/json-to-graphql-go/pkg/data"
 )
 
 func main() {
+	// "schema print" writes the SDL of the data to stdout instead of serving it, e.g. to commit it.
+	args := os.Args[1:]
+	printSchema := len(args) >= 2 && args[0] == "schema" && args[1] == "print"
+	if printSchema {
+		args = args[2:]
+	}
+
+	source := flag.String("data", data.DefaultSource, "path to a json file, a glob pattern or a directory with json files")
+	addr := flag.String("addr", ":8080", "address to listen on")
+	watch := flag.Bool("watch", true, "reload the schema on file system events instead of polling")
+	interval := flag.Duration("interval", time.Second*5, "polling interval, also used with -watch to catch changes missed by file system events")
+	debounce := flag.Duration("debounce", time.Millisecond*200, "quiet period after a file change before reloading")
+	rejectBreaking := flag.Bool("reject-breaking", false, "keep serving the current schema when reloaded data removes or retypes any existing field, argument or enum value")
+	errorCodes := flag.Bool("error-codes", false, "add extensions.code to the errors of graphql responses")
+	playground := flag.Bool("playground", true, "serve the playground page to browsers opening the graphql endpoint")
+	naming := flag.String("naming", "key", "how object types are named: key (e.g. addressObject) or path (e.g. UserAddress)")
+	connections := flag.Bool("connections", false, "add relay style connection fields next to lists of objects")
+	unions := flag.Bool("unions", false, "turn lists of objects with a type, kind or __typename field into lists of unions")
+	interfaces := flag.Bool("interfaces", false, "extract interfaces from the fields shared by object types")
+	enums := flag.Bool("enums", false, "turn string fields with few distinct values into enums")
+	nonNull := flag.Bool("non-null", false, "mark fields present and not null in every object as non-null")
+	scalars := flag.Bool("scalars", false, "type strings as DateTime, Date, UUID, Email or URL when every value has the format")
+	inferRelations := flag.Bool("infer-relations", false, "link top-level lists by keys like userId holding ids of another list")
+	relationsFile := flag.String("relations", "", "path to a json file with relations between top-level lists")
+	federation := flag.Bool("federation", false, "serve the schema as an apollo federation v2 subgraph with top-level lists of objects with an id as entities")
+	entityKeys := flag.String("entity-keys", "", "comma separated keys of entities without an id, e.g. products=sku,reviews=code")
+	if err := flag.CommandLine.Parse(args); err != nil {
+		log.Fatal(err)
+	}
+
+	// Parsing stops at the first argument that is not a flag, e.g. a misspelled command.
+	if flag.NArg() > 0 {
+		log.Fatalf("unexpected arguments %q, expected flags, optionally after \"schema print\"", flag.Args())
+	}
+
+	var namingStrategy api.NamingStrategy
+	switch *naming {
+	case "key":
+		namingStrategy = api.NamingByKey
+	case "path":
+		namingStrategy = api.NamingByPath
+	default:
+		log.Fatalf("unknown naming %q, expected key or path", *naming)
+	}
+
+	var relations []api.Relation
+	if *relationsFile != "" {
+		var err error
+		if relations, err = api.LoadRelations(*relationsFile); err != nil {
+			log.Fatalf("failed to load relations, error: %v", err)
+		}
+	}
+
+	keys := make(map[string]string)
+	for _, pair := range strings.Split(*entityKeys, ",") {
+		if pair == "" {
+			continue
+		}
+
+		list, key, ok := strings.Cut(pair, "=")
+		if !ok || list == "" || key == "" {
+			log.Fatalf("invalid entity key %q, expected list=key", pair)
+		}
+
+		keys[list] = key
+	}
+
 	ctx := context.Background()
-	app, err := api.New(api.Config{})
+
+	// Only warnings are logged while printing, to stderr, so they don't end up in the SDL.
+	var logger *slog.Logger
+	if printSchema {
+		logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	}
+
+	app, err := api.New(api.Config{
+		JSONProvider:          data.NewJSONProviderFromSource(*source),
+		RejectBreakingChanges: *rejectBreaking,
+		ErrorCodes:            *errorCodes,
+		DisablePlayground:     !*playground,
+		Logger:                logger,
+		Schema: api.SchemaOptions{
+			Naming:      namingStrategy,
+			Connections: *connections,
+			Unions:      *unions,
+			Interfaces:  *interfaces,
+			Enums:       *enums,
+			NonNull:     *nonNull,
+			Scalars:     *scalars,
+
+			InferRelations: *inferRelations,
+			Relations:      relations,
+
+			Federation: *federation,
+			EntityKeys: keys,
+		},
+	})
 	if err != nil {
 		log.Fatalf("failed to create app, error: %v", err)
 	}
 
-	app.StartBackgroundSchemaUpdate(ctx, time.Second*5)
+	if printSchema {
+		fmt.Print(app.SchemaSDL())
+
+		return
+	}
+
+	if *watch {
+		app.StartWatchSchemaUpdate(ctx, *debounce, *interval)
+	} else {
+		app.StartBackgroundSchemaUpdate(ctx, *interval)
+	}
+
+	expvar.Publish("schemaReloads", expvar.Func(func() any { return app.ReloadStats() }))
 
 	http.Handle("/graphql", app.Handler)
-	log.Println("Server is running on port 8080")
-	log.Fatal(http.ListenAndServe(":8080", nil))
+	http.Handle("/status", app.StatusHandler)
+	http.Handle("/schema.graphql", app.SchemaHandler)
+
+	log.Printf("Server is running on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, nil))
 }
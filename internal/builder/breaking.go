@@ -0,0 +1,286 @@
+package builder
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+)
+
+// fieldsType is an object or interface type.
+type fieldsType interface {
+	Name() string
+	Fields() graphql.FieldDefinitionMap
+}
+
+// BreakingChanges lists the changes of any field of the previous schema that queries written against it
+// may rely on: removed fields, fields whose type changed, fields that became nullable, removed or retyped
+// arguments, arguments and input fields that became required, removed enum values and removed union members.
+// Paths are dot separated, starting at the root query, with arguments in parentheses, e.g. "users(where).age".
+// Types shared by several fields are compared once, at the first path in alphabetical order.
+func BreakingChanges(prev, next *graphql.Schema) []string {
+	if prev == nil || next == nil {
+		return nil
+	}
+
+	var changes []string
+	compareFields(prev.QueryType(), next.QueryType(), nil, &changes, make(map[string]bool))
+	sort.Strings(changes)
+
+	return changes
+}
+
+func compareFields(prev, next fieldsType, path []string, changes *[]string, visited map[string]bool) {
+	visitKey := prev.Name() + "->" + next.Name()
+	if visited[visitKey] {
+		return
+	}
+	visited[visitKey] = true
+
+	prevFields, nextFields := prev.Fields(), next.Fields()
+	for _, name := range slices.Sorted(maps.Keys(prevFields)) {
+		if strings.HasPrefix(name, "__") {
+			continue
+		}
+
+		fieldPath := append(slices.Clone(path), name)
+
+		nextField, ok := nextFields[name]
+		if !ok {
+			*changes = append(*changes, strings.Join(fieldPath, ".")+": field removed")
+			continue
+		}
+
+		compareOutputs(prevFields[name].Type, nextField.Type, fieldPath, changes, visited)
+		compareArgs(prevFields[name].Args, nextField.Args, fieldPath, changes, visited)
+	}
+}
+
+// compareArgs compares the arguments of a field, a removed argument breaks queries passing it
+// and a new required one breaks queries not passing it.
+func compareArgs(prev, next []*graphql.Argument, path []string, changes *[]string, visited map[string]bool) {
+	nextArgs := make(map[string]*graphql.Argument, len(next))
+	for _, arg := range next {
+		nextArgs[arg.Name()] = arg
+	}
+
+	prevArgs := make(map[string]bool, len(prev))
+	for _, arg := range prev {
+		prevArgs[arg.Name()] = true
+		argPath := argumentPath(path, arg.Name())
+
+		nextArg, ok := nextArgs[arg.Name()]
+		if !ok {
+			*changes = append(*changes, strings.Join(argPath, ".")+": argument removed")
+			continue
+		}
+
+		compareInputs(arg.Type, nextArg.Type, argPath, changes, visited)
+	}
+
+	for _, arg := range next {
+		if _, required := arg.Type.(*graphql.NonNull); required && arg.DefaultValue == nil && !prevArgs[arg.Name()] {
+			*changes = append(*changes, strings.Join(argumentPath(path, arg.Name()), ".")+": required argument added")
+		}
+	}
+}
+
+// argumentPath returns the path of the argument of the field at the end of the path, e.g. "users(where)".
+func argumentPath(path []string, name string) []string {
+	res := slices.Clone(path)
+	res[len(res)-1] += "(" + name + ")"
+
+	return res
+}
+
+func compareOutputs(prev, next graphql.Output, path []string, changes *[]string, visited map[string]bool) {
+	report := func(reason string) {
+		*changes = append(*changes, fmt.Sprintf("%s: %s", strings.Join(path, "."), reason))
+	}
+
+	if prevNonNull, ok := prev.(*graphql.NonNull); ok {
+		nextNonNull, ok := next.(*graphql.NonNull)
+		if !ok {
+			report("became nullable")
+
+			return
+		}
+
+		compareOutputs(prevNonNull.OfType, nextNonNull.OfType, path, changes, visited)
+
+		return
+	}
+
+	// A nullable field becoming non-null is safe for clients.
+	if nextNonNull, ok := next.(*graphql.NonNull); ok {
+		next = nextNonNull.OfType
+	}
+
+	if prevList, ok := prev.(*graphql.List); ok {
+		nextList, ok := next.(*graphql.List)
+		if !ok {
+			report(fmt.Sprintf("type changed from %s to %s", prev, next))
+
+			return
+		}
+
+		compareOutputs(prevList.OfType, nextList.OfType, path, changes, visited)
+
+		return
+	}
+
+	if prev.Name() != next.Name() {
+		// An object may be replaced by an object of another name with the same fields, e.g. with NamingByPath.
+		if prevObject, ok := prev.(*graphql.Object); ok {
+			if nextObject, ok := next.(*graphql.Object); ok {
+				compareFields(prevObject, nextObject, path, changes, visited)
+
+				return
+			}
+		}
+
+		report(fmt.Sprintf("type changed from %s to %s", prev, next))
+
+		return
+	}
+
+	switch prev := prev.(type) {
+	case *graphql.Object:
+		if nextObject, ok := next.(*graphql.Object); ok {
+			compareFields(prev, nextObject, path, changes, visited)
+		}
+	case *graphql.Interface:
+		if nextInterface, ok := next.(*graphql.Interface); ok {
+			compareFields(prev, nextInterface, path, changes, visited)
+		}
+	case *graphql.Union:
+		if nextUnion, ok := next.(*graphql.Union); ok {
+			compareUnions(prev, nextUnion, path, changes, visited)
+		}
+	case *graphql.Enum:
+		if nextEnum, ok := next.(*graphql.Enum); ok {
+			compareEnums(prev, nextEnum, path, changes, visited)
+		}
+	}
+}
+
+// compareUnions reports removed members, the fields of the other members are compared
+// under the path of the member, e.g. "feed.TextPost.body".
+func compareUnions(prev, next *graphql.Union, path []string, changes *[]string, visited map[string]bool) {
+	nextMembers := make(map[string]*graphql.Object)
+	for _, member := range next.Types() {
+		nextMembers[member.Name()] = member
+	}
+
+	for _, member := range prev.Types() {
+		memberPath := append(slices.Clone(path), member.Name())
+
+		nextMember, ok := nextMembers[member.Name()]
+		if !ok {
+			*changes = append(*changes, strings.Join(memberPath, ".")+": union member removed")
+			continue
+		}
+
+		compareFields(member, nextMember, memberPath, changes, visited)
+	}
+}
+
+// compareEnums reports removed enum values, they break queries passing them and clients expecting them.
+func compareEnums(prev, next *graphql.Enum, path []string, changes *[]string, visited map[string]bool) {
+	if visited["enum:"+prev.Name()] {
+		return
+	}
+	visited["enum:"+prev.Name()] = true
+
+	nextValues := make(map[string]bool)
+	for _, value := range next.Values() {
+		nextValues[value.Name] = true
+	}
+
+	for _, value := range prev.Values() {
+		if !nextValues[value.Name] {
+			*changes = append(*changes, fmt.Sprintf("%s: enum value %s removed", strings.Join(path, "."), value.Name))
+		}
+	}
+}
+
+// compareInputs compares the types of arguments and input fields. Unlike outputs, inputs break
+// queries when they become required and not when they become nullable.
+func compareInputs(prev, next graphql.Input, path []string, changes *[]string, visited map[string]bool) {
+	report := func(reason string) {
+		*changes = append(*changes, fmt.Sprintf("%s: %s", strings.Join(path, "."), reason))
+	}
+
+	prevNonNull, prevRequired := prev.(*graphql.NonNull)
+	if prevRequired {
+		prev = prevNonNull.OfType
+	}
+
+	if nextNonNull, ok := next.(*graphql.NonNull); ok {
+		if !prevRequired {
+			report("became required")
+		}
+
+		next = nextNonNull.OfType
+	}
+
+	if prevList, ok := prev.(*graphql.List); ok {
+		nextList, ok := next.(*graphql.List)
+		if !ok {
+			report(fmt.Sprintf("type changed from %s to %s", prev, next))
+
+			return
+		}
+
+		compareInputs(prevList.OfType, nextList.OfType, path, changes, visited)
+
+		return
+	}
+
+	if prev.Name() != next.Name() {
+		report(fmt.Sprintf("type changed from %s to %s", prev, next))
+
+		return
+	}
+
+	switch prev := prev.(type) {
+	case *graphql.InputObject:
+		if nextObject, ok := next.(*graphql.InputObject); ok {
+			compareInputObjects(prev, nextObject, path, changes, visited)
+		}
+	case *graphql.Enum:
+		if nextEnum, ok := next.(*graphql.Enum); ok {
+			compareEnums(prev, nextEnum, path, changes, visited)
+		}
+	}
+}
+
+func compareInputObjects(prev, next *graphql.InputObject, path []string, changes *[]string, visited map[string]bool) {
+	if visited["input:"+prev.Name()] {
+		return
+	}
+	visited["input:"+prev.Name()] = true
+
+	prevFields, nextFields := prev.Fields(), next.Fields()
+	for _, name := range slices.Sorted(maps.Keys(prevFields)) {
+		fieldPath := append(slices.Clone(path), name)
+
+		nextField, ok := nextFields[name]
+		if !ok {
+			*changes = append(*changes, strings.Join(fieldPath, ".")+": input field removed")
+			continue
+		}
+
+		compareInputs(prevFields[name].Type, nextField.Type, fieldPath, changes, visited)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(nextFields)) {
+		field := nextFields[name]
+		if _, required := field.Type.(*graphql.NonNull); required && field.DefaultValue == nil && prevFields[name] == nil {
+			*changes = append(*changes, strings.Join(append(slices.Clone(path), name), ".")+": required input field added")
+		}
+	}
+}
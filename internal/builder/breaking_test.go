@@ -0,0 +1,66 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/niklod/json-to-graphql-go/internal/field"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBreakingChanges verifies that removed and retyped fields are reported.
+func TestBreakingChanges(t *testing.T) {
+	prev, _ := buildSchema(t, field.Config{}, `{
+        "user": {"name": "John", "age": 30, "address": {"city": "New York", "zip": 10001}},
+        "tags": ["a", "b"]
+    }`)
+	next, _ := buildSchema(t, field.Config{}, `{
+        "user": {"name": "John", "age": "thirty", "address": {"city": "New York"}, "email": "john@example.com"},
+        "tags": "a,b"
+    }`)
+
+	assert.Equal(t, []string{
+		"tags(limit): argument removed",
+		"tags(offset): argument removed",
+		"tags: type changed from [String] to String",
+		"user.address.zip: field removed",
+		"user.age: type changed from Int to String",
+	}, BreakingChanges(prev, next))
+}
+
+// TestAddedFieldsAreNotBreaking verifies that growing the schema is safe.
+func TestAddedFieldsAreNotBreaking(t *testing.T) {
+	prev, _ := buildSchema(t, field.Config{}, `{"user": {"name": "John"}}`)
+	next, _ := buildSchema(t, field.Config{}, `{"user": {"name": "John", "age": 30}, "items": [{"id": 1}]}`)
+
+	assert.Empty(t, BreakingChanges(prev, next))
+}
+
+// TestBreakingArguments verifies that removed input fields and enum values of arguments are reported.
+func TestBreakingArguments(t *testing.T) {
+	prev, _ := buildSchema(t, field.Config{}, `{"users": [{"id": 1, "name": "Ann", "age": 30}, {"id": 2, "name": "Bob", "age": 25}]}`)
+	next, _ := buildSchema(t, field.Config{}, `{"users": [{"id": 1, "name": "Ann"}, {"id": 2, "name": "Bob"}]}`)
+
+	assert.Equal(t, []string{
+		"user.age: field removed",
+		"users(orderBy).field: enum value age removed",
+		"users(where).age: input field removed",
+	}, BreakingChanges(prev, next))
+}
+
+// TestBreakingEnumsAndUnions verifies that removed enum values and union members are reported.
+func TestBreakingEnumsAndUnions(t *testing.T) {
+	config := field.Config{Enums: true, Unions: true}
+	prev, _ := buildSchema(t, config, `{
+        "items": [{"tier": "Gold"}, {"tier": "Silver"}, {"tier": "Gold"}, {"tier": "Silver"}],
+        "feed": [{"type": "text", "body": "hi"}, {"type": "image", "url": "a.png"}, {"type": "video", "url": "a.mp4"}]
+    }`)
+	next, _ := buildSchema(t, config, `{
+        "items": [{"tier": "Gold"}, {"tier": "Gold"}, {"tier": "Gold"}, {"tier": "Gold"}],
+        "feed": [{"type": "text", "body": "hi"}, {"type": "video", "url": "a.mp4"}]
+    }`)
+
+	assert.Equal(t, []string{
+		"feed.feedImageObject: union member removed",
+		"items.tier: enum value Silver removed",
+	}, BreakingChanges(prev, next))
+}
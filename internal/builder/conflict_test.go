@@ -0,0 +1,66 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/niklod/json-to-graphql-go/internal/field"
+	"github.com/stretchr/testify/assert"
+)
+
+const conflictTestData = `{
+    "mixed": [1, "two", true, null],
+    "matrix": [[1, 2], ["a"]],
+    "numbers": [1, 2.5],
+    "items": [
+        {"name": "sword", "size": "XL", "meta": {"rarity": "epic"}},
+        {"name": "shield", "size": 42, "meta": "none"},
+        {"name": "staff", "size": null}
+    ]
+}`
+
+// TestConflictingTypes verifies that values of different JSON types are typed as JSON.
+func TestConflictingTypes(t *testing.T) {
+	schema, report := buildSchema(t, field.Config{}, conflictTestData)
+	root := schema.QueryType()
+	items := schema.Type("itemsObject").(*graphql.Object)
+
+	assert.Equal(t, "[JSON]", fieldTypeName(root, "mixed"))
+	assert.Equal(t, "[[JSON]]", fieldTypeName(root, "matrix"))
+	assert.Equal(t, "[Float]", fieldTypeName(root, "numbers"), "Int and Float should not conflict")
+	assert.Equal(t, "JSON", fieldTypeName(items, "size"))
+	assert.Equal(t, "JSON", fieldTypeName(items, "meta"))
+	assert.Equal(t, "String", fieldTypeName(items, "name"))
+
+	expected := []field.Conflict{
+		{Path: "items.meta", Types: []string{"string", "object"}},
+		{Path: "items.size", Types: []string{"string", "number"}},
+		{Path: "matrix[][]", Types: []string{"string", "number"}},
+		{Path: "mixed[]", Types: []string{"string", "number", "bool"}},
+	}
+	assert.Equal(t, expected, report.Conflicts)
+
+	result := graphql.Do(graphql.Params{Schema: *schema, RequestString: `{ mixed matrix items { name size meta } }`})
+	assert.Empty(t, result.Errors, "GraphQL execution should not error")
+
+	data := result.Data.(map[string]interface{})
+	assert.Equal(t, []interface{}{1.0, "two", true, nil}, data["mixed"])
+	assert.Equal(t, []interface{}{[]interface{}{1.0, 2.0}, []interface{}{"a"}}, data["matrix"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "sword", "size": "XL", "meta": map[string]interface{}{"rarity": "epic"}},
+		map[string]interface{}{"name": "shield", "size": 42.0, "meta": "none"},
+		map[string]interface{}{"name": "staff", "size": nil, "meta": nil},
+	}, data["items"])
+}
+
+// TestConflictingTypesInArguments verifies that JSON fields are left out of where and orderBy.
+func TestConflictingTypesInArguments(t *testing.T) {
+	schema, _ := buildSchema(t, field.Config{}, conflictTestData)
+
+	where := schema.Type("itemsObjectWhere").(*graphql.InputObject)
+	assert.Contains(t, where.Fields(), "name")
+	assert.NotContains(t, where.Fields(), "size")
+
+	orderFields := schema.Type("itemsObjectOrderField").(*graphql.Enum)
+	assert.Len(t, orderFields.Values(), 1)
+}
@@ -0,0 +1,103 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/niklod/json-to-graphql-go/internal/field"
+	"github.com/stretchr/testify/assert"
+)
+
+const enumsTestData = `{
+    "items": [
+        {"name": "sword", "tier": "Gold", "slot": "main-hand", "rarity": "common", "tags": ["melee"]},
+        {"name": "shield", "tier": "Silver", "slot": "off-hand", "rarity": "common", "tags": ["melee", "block"]},
+        {"name": "staff", "tier": "Gold", "slot": "main-hand", "rarity": "rare", "tags": ["magic"]},
+        {"name": "bow", "tier": "Diamond", "slot": "main-hand", "rarity": "common", "tags": ["melee", "block"]},
+        {"name": "dagger", "tier": "Silver", "slot": "off-hand", "rarity": "rare"},
+        {"name": "wand", "tier": "Gold", "slot": "off-hand", "rarity": "common"}
+    ],
+    "status": "active"
+}`
+
+// TestEnumInference verifies that only repeated strings that are valid names become enums.
+func TestEnumInference(t *testing.T) {
+	schema, _ := buildSchema(t, field.Config{Enums: true}, enumsTestData)
+	items := schema.Type("itemsObject").(*graphql.Object)
+
+	tests := []struct {
+		field    string
+		expected string
+	}{
+		{field: "tier", expected: "ItemsTier"},
+		{field: "rarity", expected: "ItemsRarity"},
+		{field: "tags", expected: "[ItemsTags]"},
+		{field: "name", expected: "String"},
+		{field: "slot", expected: "String"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.field, func(t *testing.T) {
+			assert.Equal(t, test.expected, fieldTypeName(items, test.field))
+		})
+	}
+
+	assert.Equal(t, "String", fieldTypeName(schema.QueryType(), "status"), "A single sample should not be an enum")
+
+	tier := schema.Type("ItemsTier").(*graphql.Enum)
+	var values []string
+	for _, value := range tier.Values() {
+		values = append(values, value.Name)
+	}
+	assert.ElementsMatch(t, []string{"Gold", "Silver", "Diamond"}, values)
+
+	result := graphql.Do(graphql.Params{Schema: *schema, RequestString: `{
+        items(where: {tier: {in: [Gold, Diamond]}, rarity: {eq: common}}) { name tier }
+    }`})
+	assert.Empty(t, result.Errors, "GraphQL execution should not error")
+
+	expected := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"name": "sword", "tier": "Gold"},
+			map[string]interface{}{"name": "bow", "tier": "Diamond"},
+			map[string]interface{}{"name": "wand", "tier": "Gold"},
+		},
+	}
+	assert.Equal(t, expected, result.Data)
+}
+
+// TestEnumThresholds verifies the configured thresholds and allow and deny lists.
+func TestEnumThresholds(t *testing.T) {
+	schema, _ := buildSchema(t, field.Config{
+		Enums:         true,
+		EnumMaxValues: 2,
+		EnumAllow:     []string{"items.name", "status"},
+		EnumDeny:      []string{"items.rarity"},
+	}, enumsTestData)
+	items := schema.Type("itemsObject").(*graphql.Object)
+
+	assert.Equal(t, "String", fieldTypeName(items, "tier"), "Too many values should not be an enum")
+	assert.Equal(t, "String", fieldTypeName(items, "rarity"), "Denied paths should not be enums")
+	assert.Equal(t, "ItemsName", fieldTypeName(items, "name"), "Allowed paths should be enums")
+	assert.Equal(t, "Status", fieldTypeName(schema.QueryType(), "status"), "Allowed paths should be enums")
+}
+
+// TestEnumInferenceMissingKey verifies that strings of a key missing in the first object become an enum.
+func TestEnumInferenceMissingKey(t *testing.T) {
+	schema, _ := buildSchema(t, field.Config{Enums: true}, `{
+        "a": {"order": {"id": 1}},
+        "b": {"order": {"state": "OPEN"}},
+        "c": {"order": {"state": "CLOSED"}},
+        "d": {"order": {"state": "OPEN"}},
+        "e": {"order": {"state": "CLOSED"}}
+    }`)
+
+	assert.Equal(t, "OrderState", fieldTypeName(schema.Type("orderObject").(*graphql.Object), "state"))
+}
+
+// TestEnumsDisabled verifies that strings stay strings by default.
+func TestEnumsDisabled(t *testing.T) {
+	schema, _ := buildSchema(t, field.Config{}, enumsTestData)
+
+	assert.Equal(t, "String", fieldTypeName(schema.Type("itemsObject").(*graphql.Object), "tier"))
+}
@@ -0,0 +1,79 @@
+package builder
+
+import (
+	"sync"
+
+	"github.com/graphql-go/graphql"
+)
+
+// federationLink imports the Federation v2 directives used in the SDL of the subgraph.
+const federationLink = `extend schema @link(url: "https://specs.apollo.dev/federation/v2.0", import: ["@key"])`
+
+// serviceType is the Federation _Service type holding the SDL of the subgraph.
+var serviceType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "_Service",
+	Fields: graphql.Fields{
+		"sdl": &graphql.Field{Type: graphql.String},
+	},
+})
+
+// addFederationFields adds the _service and _entities root fields of a Federation v2 subgraph,
+// replacing fields of the data with the same names. The SDL served by _service leaves out these
+// fields and their types and marks the entities with @key. There is no _entities field without entities.
+func (b *GraphQLSchemaBuilder) addFederationFields(fields graphql.Fields, jsonData map[string]interface{}) {
+	entities, federated := b.fieldFactory.Entities(jsonData)
+	if !federated {
+		return
+	}
+
+	opts := sdlOptions{
+		header: federationLink,
+		hidden: map[string]bool{
+			"_Service": true, "_Entity": true, "_Any": true,
+			rootQueryName + "._service": true, rootQueryName + "._entities": true,
+		},
+		directives: make(map[string]string, len(entities)),
+	}
+
+	for _, e := range entities {
+		opts.directives[e.Type.Name()] = "@key(fields: " + quote(e.Key) + ")"
+	}
+
+	if len(entities) > 0 {
+		fields["_entities"] = b.fieldFactory.CreateEntitiesField(entities)
+	}
+
+	// The schema is complete only once the root query is, so the SDL is printed by the first request.
+	var once sync.Once
+	var sdl string
+
+	fields["_service"] = &graphql.Field{
+		Type: graphql.NewNonNull(serviceType),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			once.Do(func() {
+				sdl = printSchema(&p.Info.Schema, opts)
+			})
+
+			return map[string]interface{}{"sdl": sdl}, nil
+		},
+	}
+}
+
+// subgraphSDL returns the SDL served by the _service field of a subgraph, false for other schemas.
+func subgraphSDL(schema *graphql.Schema) (string, bool) {
+	def, ok := schema.QueryType().Fields()["_service"]
+	if !ok {
+		return "", false
+	}
+
+	// Fields of the data may be named _service too.
+	if t, ok := def.Type.(*graphql.NonNull); !ok || t.OfType != serviceType {
+		return "", false
+	}
+
+	res, err := def.Resolve(graphql.ResolveParams{Info: graphql.ResolveInfo{Schema: *schema}})
+	service, _ := res.(map[string]interface{})
+	sdl, ok := service["sdl"].(string)
+
+	return sdl, err == nil && ok
+}
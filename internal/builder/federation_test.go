@@ -0,0 +1,131 @@
+package builder
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/niklod/json-to-graphql-go/internal/field"
+	"github.com/stretchr/testify/assert"
+)
+
+const federationTestData = `{
+    "users": [{"id": 1, "name": "Alice"}, {"id": 2, "name": "Bob"}],
+    "orders": [{"id": 1, "total": 5}, {"id": 2, "total": 7}],
+    "products": [{"sku": "p-1", "title": "Pen"}, {"sku": "p-2", "title": "Ink"}],
+    "shop": {"name": "Acme"}
+}`
+
+// TestFederationSDL verifies that the subgraph SDL marks the entities with @key and leaves out federation fields.
+func TestFederationSDL(t *testing.T) {
+	schema, _ := buildSchema(t, field.Config{
+		Federation: true,
+		EntityKeys: map[string]string{"products": "sku"},
+	}, federationTestData)
+
+	result := graphql.Do(graphql.Params{Schema: *schema, RequestString: `{ _service { sdl } }`})
+	assert.Empty(t, result.Errors, "GraphQL execution should not error")
+
+	sdl := result.Data.(map[string]interface{})["_service"].(map[string]interface{})["sdl"].(string)
+	assert.Contains(t, sdl, `extend schema @link(url: "https://specs.apollo.dev/federation/v2.0", import: ["@key"])`)
+	assert.Contains(t, sdl, `type usersObject @key(fields: "id") {`)
+	assert.Contains(t, sdl, `type ordersObject @key(fields: "id") {`)
+	assert.Contains(t, sdl, `type productsObject @key(fields: "sku") {`)
+	assert.Contains(t, sdl, "type shopObject {")
+	assert.NotContains(t, sdl, "_service")
+	assert.NotContains(t, sdl, "_entities")
+	assert.NotContains(t, sdl, "_Any")
+
+	assert.Equal(t, sdl, PrintSchema(schema), "Subgraphs should print the SDL of _service")
+
+	// The parser doesn't support schema extensions, the rest of the SDL must parse.
+	_, err := parser.Parse(parser.ParseParams{Source: strings.TrimPrefix(sdl, federationLink)})
+	assert.NoError(t, err, "The SDL should parse")
+}
+
+// TestFederationEntities verifies that representations resolve to the objects of their type, even with shared ids.
+func TestFederationEntities(t *testing.T) {
+	schema, _ := buildSchema(t, field.Config{
+		Federation: true,
+		EntityKeys: map[string]string{"products": "sku"},
+	}, federationTestData)
+
+	result := graphql.Do(graphql.Params{Schema: *schema, RequestString: `query($representations: [_Any!]!) {
+        _entities(representations: $representations) {
+            __typename
+            ... on usersObject { name }
+            ... on ordersObject { total }
+            ... on productsObject { title }
+        }
+    }`, VariableValues: map[string]interface{}{
+		"representations": []interface{}{
+			map[string]interface{}{"__typename": "ordersObject", "id": "2"},
+			map[string]interface{}{"__typename": "usersObject", "id": "2"},
+			map[string]interface{}{"__typename": "productsObject", "sku": "p-1"},
+			map[string]interface{}{"__typename": "usersObject", "id": "9"},
+		},
+	}})
+	assert.Empty(t, result.Errors, "GraphQL execution should not error")
+
+	expected := map[string]interface{}{
+		"_entities": []interface{}{
+			map[string]interface{}{"__typename": "ordersObject", "total": 7},
+			map[string]interface{}{"__typename": "usersObject", "name": "Bob"},
+			map[string]interface{}{"__typename": "productsObject", "title": "Pen"},
+			nil,
+		},
+	}
+	assert.Equal(t, expected, result.Data)
+
+	result = graphql.Do(graphql.Params{Schema: *schema, RequestString: `{
+        _entities(representations: [{__typename: "usersObject", id: 1}]) { ... on usersObject { name } }
+    }`})
+	assert.Empty(t, result.Errors, "GraphQL execution should not error")
+	assert.Equal(t, map[string]interface{}{
+		"_entities": []interface{}{map[string]interface{}{"name": "Alice"}},
+	}, result.Data)
+
+	result = graphql.Do(graphql.Params{Schema: *schema, RequestString: `{
+        _entities(representations: [{__typename: "shopObject"}]) { __typename }
+    }`})
+	assert.NotEmpty(t, result.Errors, "Unknown entity types should error")
+}
+
+// TestRejectedEntityKeys verifies that configured entity keys identifying no entity are reported with the reason.
+func TestRejectedEntityKeys(t *testing.T) {
+	schema, report := buildSchema(t, field.Config{
+		Federation: true,
+		EntityKeys: map[string]string{
+			"company":  "x",
+			"shop":     "name",
+			"orders":   "total",
+			"products": "title",
+			"users":    "sku",
+		},
+	}, `{
+        "users": [{"id": 1, "name": "Alice"}, {"id": 2, "name": "Bob"}],
+        "orders": [{"id": 1, "total": 5}, {"id": 2, "total": 5}],
+        "products": [{"sku": "p-1", "title": "Pen"}, {"sku": "p-2", "title": "Ink"}],
+        "shop": {"name": "Acme"}
+    }`)
+
+	expected := []field.RejectedEntityKey{
+		{List: "company", Key: "x", Reason: `"company" is not a top-level list of objects`},
+		{List: "orders", Key: "total", Reason: `"total" is not a unique id of every object`},
+		{List: "shop", Key: "name", Reason: `"shop" is not a top-level list of objects`},
+		{List: "users", Key: "sku", Reason: `"sku" is not a unique id of every object`},
+	}
+	assert.Equal(t, expected, report.RejectedEntityKeys)
+
+	entity := schema.Type("_Entity").(*graphql.Union)
+	assert.Len(t, entity.Types(), 1, "Only the products should be an entity")
+}
+
+// TestFederationDisabled verifies that schemas are not subgraphs by default.
+func TestFederationDisabled(t *testing.T) {
+	schema, _ := buildSchema(t, field.Config{}, federationTestData)
+
+	assert.NotContains(t, schema.QueryType().Fields(), "_service")
+	assert.NotContains(t, schema.QueryType().Fields(), "_entities")
+}
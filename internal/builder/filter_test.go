@@ -0,0 +1,109 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/niklod/json-to-graphql-go/internal/field"
+	"github.com/stretchr/testify/assert"
+)
+
+const filterTestData = `{
+    "items": [
+        {"name": "sword", "price": 100, "tags": ["weapon"], "stat": {"name": "fire", "level": 5}},
+        {"name": "shield", "price": 80, "tags": ["armor"], "stat": {"name": "ice", "level": 3}},
+        {"name": "fire staff", "price": 250, "enabled": true, "stat": {"name": "fire", "level": 9},
+         "tierStats": [{"tier": "Silver", "cooldown": 3}, {"tier": "Gold", "cooldown": 2}]}
+    ]
+}`
+
+func queryNames(t *testing.T, schema *graphql.Schema, query string) []interface{} {
+	t.Helper()
+
+	result := graphql.Do(graphql.Params{Schema: *schema, RequestString: query})
+	assert.Empty(t, result.Errors, "GraphQL execution should not error")
+
+	data, ok := result.Data.(map[string]interface{})
+	assert.True(t, ok, "Result data should be a map")
+
+	items, ok := data["items"].([]interface{})
+	assert.True(t, ok, "Items should be a list")
+
+	names := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		names = append(names, item.(map[string]interface{})["name"])
+	}
+
+	return names
+}
+
+// TestWhereFilters verifies the generated where argument on lists of objects.
+func TestWhereFilters(t *testing.T) {
+	schema, _ := buildSchema(t, field.Config{}, filterTestData)
+
+	tests := []struct {
+		name     string
+		where    string
+		expected []interface{}
+	}{
+		{name: "No filter", where: `{}`, expected: []interface{}{"sword", "shield", "fire staff"}},
+		{name: "Equal", where: `{name: {eq: "shield"}}`, expected: []interface{}{"shield"}},
+		{name: "Not equal", where: `{name: {neq: "shield"}}`, expected: []interface{}{"sword", "fire staff"}},
+		{name: "In", where: `{price: {in: [80, 250]}}`, expected: []interface{}{"shield", "fire staff"}},
+		{name: "Contains", where: `{name: {contains: "fire"}}`, expected: []interface{}{"fire staff"}},
+		{name: "Range", where: `{price: {gte: 80, lt: 250}}`, expected: []interface{}{"sword", "shield"}},
+		{name: "Boolean", where: `{enabled: {eq: true}}`, expected: []interface{}{"fire staff"}},
+		{name: "Nested", where: `{stat: {name: {eq: "fire"}}}`, expected: []interface{}{"sword", "fire staff"}},
+		{
+			name:     "And",
+			where:    `{AND: [{stat: {name: {eq: "fire"}}}, {price: {lt: 200}}]}`,
+			expected: []interface{}{"sword"},
+		},
+		{
+			name:     "Or",
+			where:    `{OR: [{name: {eq: "shield"}}, {stat: {level: {gt: 8}}}]}`,
+			expected: []interface{}{"shield", "fire staff"},
+		},
+		{name: "No match", where: `{price: {gt: 1000}}`, expected: []interface{}{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			names := queryNames(t, schema, `{ items(where: `+test.where+`) { name } }`)
+			assert.Equal(t, test.expected, names)
+		})
+	}
+}
+
+// TestFilteredElementsResolveOwnFields verifies that fields of filtered elements
+// are resolved from the element itself and not from its former position.
+func TestFilteredElementsResolveOwnFields(t *testing.T) {
+	schema, _ := buildSchema(t, field.Config{}, filterTestData)
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ items(where: {price: {gt: 200}}) { name tags stat { level } tierStats(where: {tier: {eq: "Gold"}}) { cooldown } } }`,
+	})
+	assert.Empty(t, result.Errors, "GraphQL execution should not error")
+
+	items := result.Data.(map[string]interface{})["items"].([]interface{})
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"name":      "fire staff",
+			"tags":      nil,
+			"stat":      map[string]interface{}{"level": 9},
+			"tierStats": []interface{}{map[string]interface{}{"cooldown": 2}},
+		},
+	}, items)
+}
+
+// TestScalarListValues verifies that lists of scalars return the values themselves.
+func TestScalarListValues(t *testing.T) {
+	schema, _ := buildSchema(t, field.Config{}, filterTestData)
+
+	result := graphql.Do(graphql.Params{Schema: *schema, RequestString: `{ items { tags } }`})
+	assert.Empty(t, result.Errors, "GraphQL execution should not error")
+
+	items := result.Data.(map[string]interface{})["items"].([]interface{})
+	assert.Equal(t, []interface{}{"weapon"}, items[0].(map[string]interface{})["tags"])
+}
@@ -1,11 +1,26 @@
 package builder
 
-import "github.com/graphql-go/graphql"
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/niklod/json-to-graphql-go/internal/field"
+)
 
 // FieldFactory creates GraphQL fields from JSON values.
 type FieldFactory interface {
 	// CreateField returns a GraphQL field for the given key and JSON value.
 	CreateField(key string, value interface{}, depth int) *graphql.Field
+	// CreateFields returns the field named name for the given key together with its companion fields.
+	CreateFields(key, name string, value interface{}, depth int) graphql.Fields
+	// FieldNames maps the keys of an object of the given type to valid GraphQL field names.
+	FieldNames(typeName string, keys []string) map[string]string
+	// Report describes the adjustments made to the data while building the last schema.
+	Report() field.Report
+	// AddRelations adds the fields navigating between related top-level lists of the data.
+	AddRelations(data map[string]interface{})
+	// Entities returns the entities of a federated schema, false when federation is disabled.
+	Entities(data map[string]interface{}) ([]field.Entity, bool)
+	// CreateEntitiesField returns the Federation _entities field resolving the entities.
+	CreateEntitiesField(entities []field.Entity) *graphql.Field
 	// GatherUnionInfo scans JSON data and records union metadata.
 	GatherUnionInfo(data interface{})
 	ResetCache()
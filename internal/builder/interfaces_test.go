@@ -0,0 +1,78 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/niklod/json-to-graphql-go/internal/field"
+	"github.com/stretchr/testify/assert"
+)
+
+const interfacesTestData = `{
+    "sword": {"id": 1, "name": "Sword", "createdAt": "2024-01-01", "damage": 5},
+    "shield": {"id": 2, "name": "Shield", "createdAt": "2024-02-01", "armor": 3},
+    "potion": {"id": 3, "name": "Potion", "heal": 10},
+    "label": {"name": "Loot", "color": "gold"}
+}`
+
+func interfaceNames(obj *graphql.Object) []string {
+	names := []string{}
+	for _, iface := range obj.Interfaces() {
+		names = append(names, iface.Name())
+	}
+
+	return names
+}
+
+// TestInterfaces verifies that fields shared by object types are extracted into interfaces.
+func TestInterfaces(t *testing.T) {
+	schema, _ := buildSchema(t, field.Config{Interfaces: true}, interfacesTestData)
+
+	tests := []struct {
+		typeName string
+		expected []string
+	}{
+		{typeName: "swordObject", expected: []string{"HasCreatedAtIdName", "HasIdName"}},
+		{typeName: "shieldObject", expected: []string{"HasCreatedAtIdName", "HasIdName"}},
+		{typeName: "potionObject", expected: []string{"HasIdName"}},
+		{typeName: "labelObject", expected: []string{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.typeName, func(t *testing.T) {
+			assert.Equal(t, test.expected, interfaceNames(schema.Type(test.typeName).(*graphql.Object)))
+		})
+	}
+
+	iface := schema.Type("HasIdName").(*graphql.Interface)
+	assert.Equal(t, "ID", iface.Fields()["id"].Type.String())
+	assert.Len(t, iface.Fields(), 2)
+
+	result := graphql.Do(graphql.Params{Schema: *schema, RequestString: `
+        fragment named on HasIdName { id name }
+        { sword { ...named damage } potion { ...named } }
+    `})
+	assert.Empty(t, result.Errors, "GraphQL execution should not error")
+
+	expected := map[string]interface{}{
+		"sword":  map[string]interface{}{"id": "1", "name": "Sword", "damage": 5},
+		"potion": map[string]interface{}{"id": "3", "name": "Potion"},
+	}
+	assert.Equal(t, expected, result.Data)
+}
+
+// TestInterfaceMinFields verifies that interfaces need the configured number of shared fields.
+func TestInterfaceMinFields(t *testing.T) {
+	schema, _ := buildSchema(t, field.Config{Interfaces: true, InterfaceMinFields: 3}, interfacesTestData)
+
+	assert.Equal(t, []string{"HasCreatedAtIdName"}, interfaceNames(schema.Type("swordObject").(*graphql.Object)))
+	assert.Empty(t, interfaceNames(schema.Type("potionObject").(*graphql.Object)))
+	assert.Nil(t, schema.Type("HasIdName"))
+}
+
+// TestInterfacesDisabled verifies that no interfaces are extracted by default.
+func TestInterfacesDisabled(t *testing.T) {
+	schema, _ := buildSchema(t, field.Config{}, interfacesTestData)
+
+	assert.Empty(t, schema.Type("swordObject").(*graphql.Object).Interfaces())
+}
@@ -0,0 +1,98 @@
+package builder
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/niklod/json-to-graphql-go/internal/field"
+	"github.com/stretchr/testify/assert"
+)
+
+// adversarialKeysTestData has keys that are gjson path syntax next to the keys they could be confused with.
+const adversarialKeysTestData = `{
+    "version": {"major": 1, "minor": 2},
+    "version.major": 10,
+    "a*b": "star",
+    "a?b": "question",
+    "#tags": ["x", "y"],
+    "tags": ["z"],
+    "user|name": "pipe",
+    "user": {"name": "plain", "first.last": "dotted", "@type": "at", "!flag": true, "=eq": "equals"},
+    "a\\b": "backslash",
+    "%pct": "percent",
+    "items": [
+        {"sku.id": "a1", "price*": 3, "tags[]": "literal", "tags": ["t1"], "meta": {"x.y": "m1"}},
+        {"sku.id": "b2", "price*": 1, "tags[]": "literal", "tags": ["t2"], "meta": {"x.y": "m2"}}
+    ],
+    "items.0": "not an element"
+}`
+
+// TestAdversarialKeys verifies that keys containing gjson path syntax resolve literally.
+func TestAdversarialKeys(t *testing.T) {
+	schema, _ := buildSchema(t, field.Config{}, adversarialKeysTestData)
+
+	result := graphql.Do(graphql.Params{Schema: *schema, RequestString: `{
+        version { major minor }
+        versionMajor
+        aB
+        aB2
+        tags
+        tags2
+        userName
+        user { name firstLast type flag eq }
+        aB3
+        pct
+        items0
+        items(where: {skuId: {eq: "b2"}}) { skuId price tags tags2 meta { xY } }
+        sorted: items(orderBy: [{field: price}]) { skuId }
+    }`})
+	assert.Empty(t, result.Errors, "GraphQL execution should not error")
+
+	expected := map[string]interface{}{
+		"version":      map[string]interface{}{"major": 1, "minor": 2},
+		"versionMajor": 10,
+		"aB":           "star",
+		"aB2":          "question",
+		"tags":         []interface{}{"z"},
+		"tags2":        []interface{}{"x", "y"},
+		"userName":     "pipe",
+		"user": map[string]interface{}{
+			"name": "plain", "firstLast": "dotted", "type": "at", "flag": true, "eq": "equals",
+		},
+		"aB3":    "backslash",
+		"pct":    "percent",
+		"items0": "not an element",
+		"items": []interface{}{
+			map[string]interface{}{
+				"skuId": "b2", "price": 1, "tags": []interface{}{"t2"}, "tags2": "literal",
+				"meta": map[string]interface{}{"xY": "m2"},
+			},
+		},
+		"sorted": []interface{}{
+			map[string]interface{}{"skuId": "b2"},
+			map[string]interface{}{"skuId": "a1"},
+		},
+	}
+	assert.Equal(t, expected, result.Data)
+}
+
+// TestAdversarialKeyTypes verifies that keys containing the separators of type keys get their own types.
+func TestAdversarialKeyTypes(t *testing.T) {
+	schema, _ := buildSchema(t, field.Config{}, `{"items": [{"tags": ["a"], "tags[]": 1}, {"tags": ["b"], "tags[]": 2}]}`)
+	items := schema.Type("itemsObject").(*graphql.Object)
+
+	assert.Equal(t, "[String]", items.Fields()["tags"].Type.String())
+	assert.Equal(t, "Int", items.Fields()["tags2"].Type.String())
+
+	schema, _ = buildSchema(t, field.Config{Naming: field.NamingByPath}, `{"version": {"major": {"n": 1}}, "version.major": {"s": "x"}}`)
+	root := schema.QueryType()
+
+	nested := root.Fields()["version"].Type.(*graphql.Object).Fields()["major"].Type.(*graphql.Object)
+	dotted := root.Fields()["versionMajor"].Type.(*graphql.Object)
+
+	assert.NotEqual(t, nested.Name(), dotted.Name())
+	assert.Equal(t, []string{"n"}, slices.Sorted(maps.Keys(nested.Fields())))
+	assert.Equal(t, []string{"s"}, slices.Sorted(maps.Keys(dotted.Fields())))
+}
@@ -0,0 +1,76 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/niklod/json-to-graphql-go/internal/field"
+	"github.com/stretchr/testify/assert"
+)
+
+const lookupTestData = `{
+    "users": [
+        {"id": 1, "name": "Alice", "age": 30},
+        {"id": 2, "name": "Bob", "age": 25},
+        {"id": 3, "name": "Carol", "age": 35}
+    ],
+    "categories": [
+        {"categoryId": "a", "title": "Tools"},
+        {"categoryId": "b", "title": "Toys"}
+    ],
+    "inventory": [{"id": "s1", "qty": 2}],
+    "tags": [{"name": "x"}, {"name": "y"}],
+    "orders": [{"id": 1}, {"id": 1}],
+    "item": {"name": "current"},
+    "items": [{"id": 5, "name": "sword"}]
+}`
+
+// TestLookupFields verifies which root lists get a lookup by id.
+func TestLookupFields(t *testing.T) {
+	schema, _ := buildSchema(t, field.Config{}, lookupTestData)
+	root := schema.QueryType().Fields()
+
+	assert.Equal(t, "usersObject", root["user"].Type.String())
+	assert.Equal(t, "categoriesObject", root["category"].Type.String(), "<name>Id should identify the objects")
+	assert.Equal(t, "inventoryObject", root["inventoryById"].Type.String())
+	assert.NotContains(t, root, "tag", "Objects without ids should not be looked up")
+	assert.NotContains(t, root, "order", "Duplicate ids should not be looked up")
+	assert.Equal(t, "itemObject", root["item"].Type.String(), "Lookups should not shadow fields of the data")
+
+	assert.Equal(t, "ID", fieldTypeName(schema.Type("usersObject").(*graphql.Object), "id"))
+	assert.Equal(t, "String", fieldTypeName(schema.Type("categoriesObject").(*graphql.Object), "categoryId"))
+}
+
+// TestLookupByID verifies that objects are looked up by one or many ids.
+func TestLookupByID(t *testing.T) {
+	schema, _ := buildSchema(t, field.Config{}, lookupTestData)
+
+	result := graphql.Do(graphql.Params{Schema: *schema, RequestString: `{
+        bob: user(id: 2) { id name }
+        carol: user(id: "3") { name }
+        nobody: user(id: 9) { name }
+        category(id: "b") { title }
+        inventoryById(id: "s1") { qty }
+        users(ids: [3, 1, 7]) { name }
+        older: users(ids: [3, 1, 2], where: {age: {gte: 30}}, limit: 1) { name }
+        none: users(ids: []) { name }
+    }`})
+	assert.Empty(t, result.Errors, "GraphQL execution should not error")
+
+	expected := map[string]interface{}{
+		"bob":           map[string]interface{}{"id": "2", "name": "Bob"},
+		"carol":         map[string]interface{}{"name": "Carol"},
+		"nobody":        nil,
+		"category":      map[string]interface{}{"title": "Toys"},
+		"inventoryById": map[string]interface{}{"qty": 2},
+		"users": []interface{}{
+			map[string]interface{}{"name": "Carol"},
+			map[string]interface{}{"name": "Alice"},
+		},
+		"older": []interface{}{
+			map[string]interface{}{"name": "Carol"},
+		},
+		"none": []interface{}{},
+	}
+	assert.Equal(t, expected, result.Data)
+}
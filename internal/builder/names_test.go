@@ -0,0 +1,114 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/niklod/json-to-graphql-go/internal/field"
+	"github.com/stretchr/testify/assert"
+)
+
+const namesTestData = `{
+    "user-profile": {
+        "first-name": "John",
+        "firstName": "Johnny",
+        "2fa_enabled": true,
+        "@type": "Person",
+        "$ref": "#/users/1",
+        "home address": {"zip-code": "10001"}
+    },
+    "line-items": [
+        {"sku-id": "a", "unit price": 3},
+        {"sku-id": "b", "unit price": 1},
+        {"sku-id": "c", "unit price": 2}
+    ]
+}`
+
+// TestInvalidKeys verifies that keys which are not valid GraphQL names are renamed
+// and still resolve from the original JSON keys.
+func TestInvalidKeys(t *testing.T) {
+	schema, _ := buildSchema(t, field.Config{}, namesTestData)
+
+	result := graphql.Do(graphql.Params{Schema: *schema, RequestString: `{
+        userProfile { firstName firstName2 _2faEnabled type ref homeAddress { zipCode } }
+    }`})
+	assert.Empty(t, result.Errors, "GraphQL execution should not error")
+
+	expected := map[string]interface{}{
+		"userProfile": map[string]interface{}{
+			"firstName":   "Johnny",
+			"firstName2":  "John",
+			"_2faEnabled": true,
+			"type":        "Person",
+			"ref":         "#/users/1",
+			"homeAddress": map[string]interface{}{"zipCode": "10001"},
+		},
+	}
+	assert.Equal(t, expected, result.Data)
+}
+
+// TestInvalidKeysInArguments verifies that where and orderBy refer to renamed fields.
+func TestInvalidKeysInArguments(t *testing.T) {
+	schema, _ := buildSchema(t, field.Config{}, namesTestData)
+
+	result := graphql.Do(graphql.Params{Schema: *schema, RequestString: `{
+        lineItems(where: {unitPrice: {gte: 2}}, orderBy: [{field: unitPrice}]) { skuId }
+    }`})
+	assert.Empty(t, result.Errors, "GraphQL execution should not error")
+
+	expected := map[string]interface{}{
+		"lineItems": []interface{}{
+			map[string]interface{}{"skuId": "c"},
+			map[string]interface{}{"skuId": "a"},
+		},
+	}
+	assert.Equal(t, expected, result.Data)
+}
+
+// TestRenameReport verifies that every renamed key is reported with its type.
+func TestRenameReport(t *testing.T) {
+	_, report := buildSchema(t, field.Config{}, namesTestData)
+
+	expected := []field.Rename{
+		{Type: "RootQuery", Key: "line-items", Field: "lineItems"},
+		{Type: "RootQuery", Key: "user-profile", Field: "userProfile"},
+		{Type: "homeAddressObject", Key: "zip-code", Field: "zipCode"},
+		{Type: "lineItemsObject", Key: "sku-id", Field: "skuId"},
+		{Type: "lineItemsObject", Key: "unit price", Field: "unitPrice"},
+		{Type: "userProfileObject", Key: "$ref", Field: "ref"},
+		{Type: "userProfileObject", Key: "2fa_enabled", Field: "_2faEnabled"},
+		{Type: "userProfileObject", Key: "@type", Field: "type"},
+		{Type: "userProfileObject", Key: "first-name", Field: "firstName2"},
+		{Type: "userProfileObject", Key: "home address", Field: "homeAddress"},
+	}
+	assert.Equal(t, expected, report.Renamed)
+}
+
+// TestTypeNameCollisions verifies that keys sanitized to the same type name get types of their own.
+func TestTypeNameCollisions(t *testing.T) {
+	schema, report := buildSchema(t, field.Config{}, `{
+        "user-name": {"first": "John"},
+        "userName": {"age": 7},
+        "user name": {"last": "Doe"}
+    }`)
+
+	assert.Equal(t, []field.TypeRename{
+		{Key: "user-name", Type: "userNameObject2"},
+		{Key: "userName", Type: "userNameObject3"},
+	}, report.RenamedTypes)
+
+	root := schema.QueryType()
+	assert.Equal(t, "userNameObject", fieldTypeName(root, "userName2"))
+
+	result := graphql.Do(graphql.Params{Schema: *schema, RequestString: `{
+        userName { age }
+        userName2 { last }
+        userName3 { first }
+    }`})
+	assert.Empty(t, result.Errors, "GraphQL execution should not error")
+	assert.Equal(t, map[string]interface{}{
+		"userName":  map[string]interface{}{"age": 7},
+		"userName2": map[string]interface{}{"last": "Doe"},
+		"userName3": map[string]interface{}{"first": "John"},
+	}, result.Data)
+}
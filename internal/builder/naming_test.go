@@ -0,0 +1,88 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/niklod/json-to-graphql-go/internal/field"
+	"github.com/stretchr/testify/assert"
+)
+
+const namingTestData = `{
+    "user": {"name": "John", "address": {"city": "New York", "zip": 10001}},
+    "company": {"name": "Acme", "address": {"street": "1 Main St", "floors": [1, 2]}},
+    "users": [
+        {"name": "Alice", "address": {"city": "Boston", "zip": 2101}},
+        {"name": "Bob", "home_address": {"city": "Austin"}}
+    ]
+}`
+
+// TestPathNaming verifies that objects with the same key under different parents get their own types.
+func TestPathNaming(t *testing.T) {
+	schema, _ := buildSchema(t, field.Config{Naming: field.NamingByPath}, namingTestData)
+	root := schema.QueryType()
+
+	tests := []struct {
+		name     string
+		obj      *graphql.Object
+		field    string
+		expected string
+	}{
+		{name: "Root object", obj: root, field: "user", expected: "User"},
+		{name: "Nested object", obj: schema.Type("User").(*graphql.Object), field: "address", expected: "UserAddress"},
+		{name: "Other parent", obj: schema.Type("Company").(*graphql.Object), field: "address", expected: "CompanyAddress"},
+		{name: "List elements", obj: root, field: "users", expected: "[Users]"},
+		{name: "Object in list", obj: schema.Type("Users").(*graphql.Object), field: "address", expected: "UsersAddress"},
+		{name: "PascalCase", obj: schema.Type("Users").(*graphql.Object), field: "home_address", expected: "UsersHomeAddress"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, fieldTypeName(test.obj, test.field))
+		})
+	}
+
+	userAddress := schema.Type("UserAddress").(*graphql.Object).Fields()
+	assert.Contains(t, userAddress, "zip")
+	assert.NotContains(t, userAddress, "street", "Unrelated shapes should not be merged")
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ user { address { city } } company { address { street floors } } }`,
+	})
+	assert.Empty(t, result.Errors, "GraphQL execution should not error")
+
+	expected := map[string]interface{}{
+		"user":    map[string]interface{}{"address": map[string]interface{}{"city": "New York"}},
+		"company": map[string]interface{}{"address": map[string]interface{}{"street": "1 Main St", "floors": []interface{}{1, 2}}},
+	}
+	assert.Equal(t, expected, result.Data)
+}
+
+// TestPathNamingCollisions verifies that paths with the same type name share the type only when compatible.
+func TestPathNamingCollisions(t *testing.T) {
+	schema, _ := buildSchema(t, field.Config{Naming: field.NamingByPath}, `{
+        "user": {"address": {"city": "New York"}, "profile": {"bio": "hi"}},
+        "user_address": {"city": "Boston"},
+        "user_profile": {"bio": 1.5}
+    }`)
+	root := schema.QueryType()
+	user := schema.Type("User").(*graphql.Object)
+
+	assert.Equal(t, "UserAddress", fieldTypeName(user, "address"))
+	assert.Equal(t, "UserAddress", fieldTypeName(root, "user_address"), "Compatible shapes should share the type")
+
+	assert.Equal(t, "UserProfile", fieldTypeName(user, "profile"))
+	assert.Equal(t, "UserProfile2", fieldTypeName(root, "user_profile"), "Incompatible shapes should get their own type")
+}
+
+// TestKeyNamingByDefault verifies that objects are still named by their bare key by default.
+func TestKeyNamingByDefault(t *testing.T) {
+	schema, _ := buildSchema(t, field.Config{}, namingTestData)
+
+	user := schema.Type("userObject").(*graphql.Object)
+	company := schema.Type("companyObject").(*graphql.Object)
+
+	assert.Equal(t, "addressObject", fieldTypeName(user, "address"))
+	assert.Equal(t, "addressObject", fieldTypeName(company, "address"))
+}
@@ -0,0 +1,80 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/niklod/json-to-graphql-go/internal/field"
+	"github.com/stretchr/testify/assert"
+)
+
+const nonNullTestData = `{
+    "items": [
+        {"id": 1, "name": "sword", "price": null, "tags": ["melee"], "stat": {"level": 5, "value": 50}},
+        {"id": 2, "name": "shield", "price": 10, "tags": ["block", null], "stat": {"level": 3}},
+        {"id": 3, "name": "staff", "tags": ["magic"], "stat": {"level": 1, "value": 10}}
+    ],
+    "matrix": [[1, 2], [3]],
+    "owner": {"name": "John", "email": null},
+    "tags": ["a", "b"],
+    "empty": {"list": []}
+}`
+
+// TestNonNullInference verifies that only fields present and not null in every object are non-null.
+func TestNonNullInference(t *testing.T) {
+	schema, _ := buildSchema(t, field.Config{NonNull: true, Connections: true}, nonNullTestData)
+	root := schema.QueryType()
+	items := schema.Type("itemsObject").(*graphql.Object)
+	stat := schema.Type("statObject").(*graphql.Object)
+	owner := schema.Type("ownerObject").(*graphql.Object)
+
+	tests := []struct {
+		name     string
+		obj      *graphql.Object
+		field    string
+		expected string
+	}{
+		{name: "List of objects", obj: root, field: "items", expected: "[itemsObject!]!"},
+		{name: "Nested lists", obj: root, field: "matrix", expected: "[[Int!]!]!"},
+		{name: "List of scalars", obj: root, field: "tags", expected: "[String!]!"},
+		{name: "Always present", obj: items, field: "id", expected: "ID!"},
+		{name: "Sometimes null", obj: items, field: "price", expected: "Int"},
+		{name: "Null elements", obj: items, field: "tags", expected: "[String]!"},
+		{name: "Object", obj: items, field: "stat", expected: "statObject!"},
+		{name: "Sometimes missing", obj: stat, field: "value", expected: "Int"},
+		{name: "Nested field", obj: stat, field: "level", expected: "Int!"},
+		{name: "Null", obj: owner, field: "email", expected: "String"},
+		{name: "Empty list", obj: schema.Type("emptyObject").(*graphql.Object), field: "list", expected: "[String]"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.obj.Fields()[test.field].Type.String())
+		})
+	}
+
+	assert.Contains(t, root.Fields(), "itemsConnection", "Non-null lists should still have connections")
+
+	result := graphql.Do(graphql.Params{Schema: *schema, RequestString: `{
+        items(where: {stat: {level: {gte: 3}}}) { id price tags stat { level value } }
+        matrix
+    }`})
+	assert.Empty(t, result.Errors, "GraphQL execution should not error")
+
+	expected := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"id": "1", "price": nil, "tags": []interface{}{"melee"}, "stat": map[string]interface{}{"level": 5, "value": 50}},
+			map[string]interface{}{"id": "2", "price": 10, "tags": []interface{}{"block", nil}, "stat": map[string]interface{}{"level": 3, "value": nil}},
+		},
+		"matrix": []interface{}{[]interface{}{1, 2}, []interface{}{3}},
+	}
+	assert.Equal(t, expected, result.Data)
+}
+
+// TestNonNullDisabled verifies that every field is nullable by default.
+func TestNonNullDisabled(t *testing.T) {
+	schema, _ := buildSchema(t, field.Config{}, nonNullTestData)
+
+	assert.Equal(t, "[itemsObject]", schema.QueryType().Fields()["items"].Type.String())
+	assert.Equal(t, "ID", schema.Type("itemsObject").(*graphql.Object).Fields()["id"].Type.String())
+}
@@ -0,0 +1,108 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/niklod/json-to-graphql-go/internal/field"
+	"github.com/stretchr/testify/assert"
+)
+
+const numberTestData = `{
+    "count": 3,
+    "ratio": 0.5,
+    "big": 3000000000,
+    "items": [
+        {"qty": 1, "price": 10, "stat": {"level": 1, "weight": 2}},
+        {"qty": 2, "price": 12.5, "stat": {"level": 2, "weight": 1.5}},
+        {"qty": 3, "price": 8}
+    ],
+    "stat": {"level": 7, "weight": 3},
+    "scores": [1, 2, 3],
+    "readings": [1, 2.5],
+    "matrix": [[1, 2], [3, 4]],
+    "explicit": 1.0
+}`
+
+func fieldTypeName(obj *graphql.Object, name string) string {
+	def, ok := obj.Fields()[name]
+	if !ok {
+		return ""
+	}
+
+	return def.Type.String()
+}
+
+// TestIntInference verifies that numbers are Int only when every observed value is an integer within 32 bits.
+func TestIntInference(t *testing.T) {
+	schema, _ := buildSchema(t, field.Config{}, numberTestData)
+
+	root := schema.QueryType()
+	item := schema.Type("itemsObject").(*graphql.Object)
+	stat := schema.Type("statObject").(*graphql.Object)
+
+	tests := []struct {
+		name     string
+		obj      *graphql.Object
+		field    string
+		expected string
+	}{
+		{name: "Integer", obj: root, field: "count", expected: "Int"},
+		{name: "Fraction", obj: root, field: "ratio", expected: "Float"},
+		{name: "Out of 32-bit range", obj: root, field: "big", expected: "Float"},
+		{name: "Written as float", obj: root, field: "explicit", expected: "Float"},
+		{name: "Integers in every element", obj: item, field: "qty", expected: "Int"},
+		{name: "Mixed numbers in elements", obj: item, field: "price", expected: "Float"},
+		{name: "Integers across merged objects", obj: stat, field: "level", expected: "Int"},
+		{name: "Mixed numbers across merged objects", obj: stat, field: "weight", expected: "Float"},
+		{name: "List of integers", obj: root, field: "scores", expected: "[Int]"},
+		{name: "List of mixed numbers", obj: root, field: "readings", expected: "[Float]"},
+		{name: "Nested list of integers", obj: root, field: "matrix", expected: "[[Int]]"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, fieldTypeName(test.obj, test.field))
+		})
+	}
+}
+
+// TestIntValues verifies that Int and Float fields resolve to the values of the data.
+func TestIntValues(t *testing.T) {
+	schema, _ := buildSchema(t, field.Config{}, numberTestData)
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ count ratio scores items(where: {qty: {in: [1, 2]}}) { qty price } }`,
+	})
+	assert.Empty(t, result.Errors, "GraphQL execution should not error")
+
+	expected := map[string]interface{}{
+		"count":  3,
+		"ratio":  0.5,
+		"scores": []interface{}{1, 2, 3},
+		"items": []interface{}{
+			map[string]interface{}{"qty": 1, "price": 10.0},
+			map[string]interface{}{"qty": 2, "price": 12.5},
+		},
+	}
+	assert.Equal(t, expected, result.Data)
+}
+
+// TestMissingKeyInference verifies that keys missing in the first object of a type are typed
+// by the values of the other objects.
+func TestMissingKeyInference(t *testing.T) {
+	data := `{"a": {"address": {"city": "x"}}, "b": {"address": {"zip": 1, "open": true, "rating": 4.5}}}`
+
+	schema, _ := buildSchema(t, field.Config{}, data)
+	address := schema.Type("addressObject").(*graphql.Object)
+
+	assert.Equal(t, "String", fieldTypeName(address, "city"))
+	assert.Equal(t, "Int", fieldTypeName(address, "zip"))
+	assert.Equal(t, "Boolean", fieldTypeName(address, "open"))
+	assert.Equal(t, "Float", fieldTypeName(address, "rating"))
+
+	// An object added before the one holding the key must not retype it.
+	prev, _ := buildSchema(t, field.Config{}, `{"b": {"address": {"zip": 1, "open": true, "rating": 4.5}}}`)
+	assert.Empty(t, BreakingChanges(prev, schema))
+}
@@ -0,0 +1,118 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/niklod/json-to-graphql-go/internal/field"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLimitOffsetOrderBy verifies the limit, offset and orderBy arguments on list fields.
+func TestLimitOffsetOrderBy(t *testing.T) {
+	schema, _ := buildSchema(t, field.Config{}, filterTestData)
+
+	tests := []struct {
+		name     string
+		args     string
+		expected []interface{}
+	}{
+		{name: "Limit", args: `limit: 2`, expected: []interface{}{"sword", "shield"}},
+		{name: "Offset", args: `offset: 1`, expected: []interface{}{"shield", "fire staff"}},
+		{name: "Offset past the end", args: `offset: 5`, expected: []interface{}{}},
+		{name: "Order ascending", args: `orderBy: [{field: price}]`, expected: []interface{}{"shield", "sword", "fire staff"}},
+		{
+			name:     "Order descending",
+			args:     `orderBy: [{field: name, direction: DESC}]`,
+			expected: []interface{}{"sword", "shield", "fire staff"},
+		},
+		{
+			name:     "Nulls last",
+			args:     `orderBy: [{field: enabled, direction: DESC}, {field: price}]`,
+			expected: []interface{}{"fire staff", "shield", "sword"},
+		},
+		{
+			name:     "Filter, order and page",
+			args:     `where: {price: {lt: 200}}, orderBy: [{field: price}], offset: 1, limit: 1`,
+			expected: []interface{}{"sword"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			names := queryNames(t, schema, `{ items(`+test.args+`) { name } }`)
+			assert.Equal(t, test.expected, names)
+		})
+	}
+
+	result := graphql.Do(graphql.Params{Schema: *schema, RequestString: `{ items(limit: -1) { name } }`})
+	assert.NotEmpty(t, result.Errors, "Negative limit should error")
+}
+
+// TestScalarListLimit verifies that lists of scalars can be paged too.
+func TestScalarListLimit(t *testing.T) {
+	schema, _ := buildSchema(t, field.Config{}, `{"tags": ["a", "b", "c"]}`)
+
+	result := graphql.Do(graphql.Params{Schema: *schema, RequestString: `{ tags(offset: 1, limit: 1) }`})
+	assert.Empty(t, result.Errors, "GraphQL execution should not error")
+	assert.Equal(t, map[string]interface{}{"tags": []interface{}{"b"}}, result.Data)
+}
+
+// TestConnections verifies the opt-in Relay style connection fields.
+func TestConnections(t *testing.T) {
+	schema, _ := buildSchema(t, field.Config{Connections: true}, filterTestData)
+
+	page := func(t *testing.T, args string) map[string]interface{} {
+		t.Helper()
+
+		query := `{ itemsConnection(` + args + `) {
+            totalCount
+            edges { cursor node { name } }
+            pageInfo { hasNextPage hasPreviousPage startCursor endCursor }
+        } }`
+		result := graphql.Do(graphql.Params{Schema: *schema, RequestString: query})
+		assert.Empty(t, result.Errors, "GraphQL execution should not error")
+
+		return result.Data.(map[string]interface{})["itemsConnection"].(map[string]interface{})
+	}
+
+	nodeNames := func(connection map[string]interface{}) []interface{} {
+		names := []interface{}{}
+		for _, edge := range connection["edges"].([]interface{}) {
+			names = append(names, edge.(map[string]interface{})["node"].(map[string]interface{})["name"])
+		}
+
+		return names
+	}
+
+	first := page(t, `first: 2, orderBy: [{field: price}]`)
+	assert.Equal(t, 3, first["totalCount"])
+	assert.Equal(t, []interface{}{"shield", "sword"}, nodeNames(first))
+
+	pageInfo := first["pageInfo"].(map[string]interface{})
+	assert.Equal(t, true, pageInfo["hasNextPage"])
+	assert.Equal(t, false, pageInfo["hasPreviousPage"])
+
+	next := page(t, `first: 2, after: "`+pageInfo["endCursor"].(string)+`", orderBy: [{field: price}]`)
+	assert.Equal(t, []interface{}{"fire staff"}, nodeNames(next))
+	assert.Equal(t, false, next["pageInfo"].(map[string]interface{})["hasNextPage"])
+	assert.Equal(t, true, next["pageInfo"].(map[string]interface{})["hasPreviousPage"])
+
+	last := page(t, `last: 1, before: "`+pageInfo["endCursor"].(string)+`"`)
+	assert.Equal(t, []interface{}{"sword"}, nodeNames(last))
+
+	filtered := page(t, `where: {stat: {name: {eq: "fire"}}}`)
+	assert.Equal(t, 2, filtered["totalCount"])
+	assert.Equal(t, []interface{}{"sword", "fire staff"}, nodeNames(filtered))
+
+	result := graphql.Do(graphql.Params{Schema: *schema, RequestString: `{ itemsConnection(after: "nope") { totalCount } }`})
+	assert.NotEmpty(t, result.Errors, "Invalid cursor should error")
+}
+
+// TestConnectionsDisabled verifies that connection fields are only added when enabled.
+func TestConnectionsDisabled(t *testing.T) {
+	schema, _ := buildSchema(t, field.Config{}, filterTestData)
+
+	_, ok := schema.QueryType().Fields()["itemsConnection"]
+	assert.False(t, ok, "Connection field should not exist by default")
+}
@@ -0,0 +1,146 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/niklod/json-to-graphql-go/internal/field"
+	"github.com/stretchr/testify/assert"
+)
+
+const relationsTestData = `{
+    "users": [
+        {"id": 1, "name": "Alice"},
+        {"id": 2, "name": "Bob"}
+    ],
+    "orders": [
+        {"id": 10, "userId": 1, "total": 5},
+        {"id": 11, "userId": 2, "total": 7},
+        {"id": 12, "userId": 1, "total": 9},
+        {"id": 13, "userId": null, "total": 1}
+    ],
+    "tags": [
+        {"id": "a", "label": "new"},
+        {"id": "b", "label": "sale"}
+    ],
+    "products": [
+        {"id": "p1", "tagIds": ["a", "b"], "categoryId": 99},
+        {"id": "p2", "tagIds": ["b"]}
+    ],
+    "categories": [{"id": 1}]
+}`
+
+// TestInferRelations verifies that keys holding ids of another list navigate to its objects and back.
+func TestInferRelations(t *testing.T) {
+	schema, report := buildSchema(t, field.Config{InferRelations: true}, relationsTestData)
+
+	expectedRelations := []field.Relation{
+		{From: "orders", Key: "userId", To: "users", Field: "user", Reverse: "orders"},
+		{From: "products", Key: "tagIds", To: "tags", Field: "tags", Reverse: "products"},
+	}
+	assert.Equal(t, expectedRelations, report.Relations, "Ids missing from the other list should not be related")
+
+	assert.NotContains(t, schema.Type("ordersObjectWhere").(*graphql.InputObject).Fields(), "user",
+		"Relations should not be filtered by")
+
+	result := graphql.Do(graphql.Params{Schema: *schema, RequestString: `{
+        users { name orders(where: {total: {gte: 6}}) { id } }
+        orders(limit: 2) { user { name } }
+        product(id: "p1") { tags { label } }
+        tags(ids: ["b"]) { products(orderBy: [{field: id, direction: DESC}]) { id } }
+    }`})
+	assert.Empty(t, result.Errors, "GraphQL execution should not error")
+
+	expected := map[string]interface{}{
+		"users": []interface{}{
+			map[string]interface{}{"name": "Alice", "orders": []interface{}{map[string]interface{}{"id": "12"}}},
+			map[string]interface{}{"name": "Bob", "orders": []interface{}{map[string]interface{}{"id": "11"}}},
+		},
+		"orders": []interface{}{
+			map[string]interface{}{"user": map[string]interface{}{"name": "Alice"}},
+			map[string]interface{}{"user": map[string]interface{}{"name": "Bob"}},
+		},
+		"product": map[string]interface{}{
+			"tags": []interface{}{map[string]interface{}{"label": "new"}, map[string]interface{}{"label": "sale"}},
+		},
+		"tags": []interface{}{
+			map[string]interface{}{"products": []interface{}{map[string]interface{}{"id": "p2"}, map[string]interface{}{"id": "p1"}}},
+		},
+	}
+	assert.Equal(t, expected, result.Data)
+}
+
+// TestConfiguredRelations verifies that configured relations rename and drop inferred ones.
+func TestConfiguredRelations(t *testing.T) {
+	schema, report := buildSchema(t, field.Config{
+		InferRelations: true,
+		Relations: []field.Relation{
+			{From: "orders", Key: "userId", To: "users", Field: "customer", Reverse: "purchases"},
+			{From: "products", Key: "tagIds"},
+			{From: "products", Key: "categoryId", To: "categories", Reverse: "-"},
+		},
+	}, relationsTestData)
+
+	expectedRelations := []field.Relation{
+		{From: "orders", Key: "userId", To: "users", Field: "customer", Reverse: "purchases"},
+		{From: "products", Key: "categoryId", To: "categories", Field: "category", Reverse: "-"},
+	}
+	assert.Equal(t, expectedRelations, report.Relations)
+
+	assert.NotContains(t, schema.Type("productsObject").(*graphql.Object).Fields(), "tags")
+	assert.NotContains(t, schema.Type("categoriesObject").(*graphql.Object).Fields(), "products")
+
+	result := graphql.Do(graphql.Params{Schema: *schema, RequestString: `{
+        user(id: 2) { purchases { customer { name } } }
+        products { category { id } }
+    }`})
+	assert.Empty(t, result.Errors, "GraphQL execution should not error")
+
+	expected := map[string]interface{}{
+		"user": map[string]interface{}{
+			"purchases": []interface{}{map[string]interface{}{"customer": map[string]interface{}{"name": "Bob"}}},
+		},
+		"products": []interface{}{
+			map[string]interface{}{"category": nil},
+			map[string]interface{}{"category": nil},
+		},
+	}
+	assert.Equal(t, expected, result.Data)
+}
+
+// TestRejectedRelations verifies that configured relations that can't be added are reported with the reason.
+func TestRejectedRelations(t *testing.T) {
+	schema, report := buildSchema(t, field.Config{
+		Relations: []field.Relation{
+			{From: "invoices", Key: "userId", To: "users"},
+			{From: "orders", Key: "userId", To: "customers"},
+			{From: "orders", Key: "buyerId", To: "users"},
+			{From: "orders", Key: "userId", To: "users", Field: "total"},
+			{From: "products", Key: "tagIds", To: "tags", Field: "tag list"},
+		},
+	}, relationsTestData)
+
+	expectedRejected := []field.RejectedRelation{
+		{Relation: field.Relation{From: "invoices", Key: "userId", To: "users"},
+			Reason: `"invoices" is not a top-level list of objects`},
+		{Relation: field.Relation{From: "orders", Key: "buyerId", To: "users"},
+			Reason: `"buyerId" holds no ids`},
+		{Relation: field.Relation{From: "orders", Key: "userId", To: "customers"},
+			Reason: `"customers" is not a top-level list of objects`},
+		{Relation: field.Relation{From: "orders", Key: "userId", To: "users", Field: "total", Reverse: "orders"},
+			Reason: `field "total" exists already`},
+		{Relation: field.Relation{From: "products", Key: "tagIds", To: "tags", Field: "tag list", Reverse: "products"},
+			Reason: `"tag list" is not a valid field name`},
+	}
+	assert.Equal(t, expectedRejected, report.RejectedRelations)
+	assert.Empty(t, report.Relations)
+	assert.NotContains(t, schema.Type("usersObject").(*graphql.Object).Fields(), "orders")
+}
+
+// TestRelationsDisabled verifies that no relations are added by default.
+func TestRelationsDisabled(t *testing.T) {
+	schema, report := buildSchema(t, field.Config{}, relationsTestData)
+
+	assert.Empty(t, report.Relations)
+	assert.NotContains(t, schema.Type("ordersObject").(*graphql.Object).Fields(), "user")
+}
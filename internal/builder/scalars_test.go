@@ -0,0 +1,116 @@
+package builder
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/niklod/json-to-graphql-go/internal/field"
+	"github.com/stretchr/testify/assert"
+)
+
+const scalarsTestData = `{
+    "users": [
+        {
+            "uid": "123e4567-e89b-12d3-a456-426614174000",
+            "email": "alice@example.com",
+            "site": "https://alice.example.com",
+            "born": "1990-04-01",
+            "seen": "2024-01-02T15:04:05Z",
+            "color": "#ff0000",
+            "note": "2024-01-02"
+        },
+        {
+            "uid": "9b2f6c1e-3d4a-4f5b-8c7d-0e1f2a3b4c5d",
+            "email": "bob@example.com",
+            "site": "http://bob.example.com/home",
+            "born": "1985-12-24",
+            "seen": "2024-03-04T05:06:07.123+02:00",
+            "color": "#00ff00",
+            "note": "call back"
+        }
+    ]
+}`
+
+var colorScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:         "Color",
+	Serialize:    func(value interface{}) interface{} { return value },
+	ParseValue:   func(value interface{}) interface{} { return value },
+	ParseLiteral: func(valueAST ast.Value) interface{} { return valueAST.GetValue() },
+})
+
+// TestScalarDetection verifies that strings matching a format in every sample get its scalar.
+func TestScalarDetection(t *testing.T) {
+	schema, _ := buildSchema(t, field.Config{
+		Scalars: true,
+		ScalarDetectors: []field.ScalarDetector{{
+			Scalar: colorScalar,
+			Detect: func(value string) bool { return strings.HasPrefix(value, "#") && len(value) == 7 },
+		}},
+	}, scalarsTestData)
+	users := schema.Type("usersObject").(*graphql.Object)
+
+	tests := []struct {
+		field    string
+		expected string
+	}{
+		{field: "uid", expected: "UUID"},
+		{field: "email", expected: "Email"},
+		{field: "site", expected: "URL"},
+		{field: "born", expected: "Date"},
+		{field: "seen", expected: "DateTime"},
+		{field: "color", expected: "Color"},
+		{field: "note", expected: "String"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.field, func(t *testing.T) {
+			assert.Equal(t, test.expected, fieldTypeName(users, test.field))
+		})
+	}
+
+	result := graphql.Do(graphql.Params{Schema: *schema, RequestString: `{
+        users(where: {uid: {eq: "9b2f6c1e-3d4a-4f5b-8c7d-0e1f2a3b4c5d"}}) { email seen born }
+    }`})
+	assert.Empty(t, result.Errors, "GraphQL execution should not error")
+
+	expected := map[string]interface{}{
+		"users": []interface{}{
+			map[string]interface{}{"email": "bob@example.com", "seen": "2024-03-04T05:06:07.123+02:00", "born": "1985-12-24"},
+		},
+	}
+	assert.Equal(t, expected, result.Data)
+
+	result = graphql.Do(graphql.Params{Schema: *schema, RequestString: `{
+        users(where: {born: {eq: "yesterday"}}) { email }
+    }`})
+	assert.NotEmpty(t, result.Errors, "Arguments not matching the format should be rejected")
+}
+
+// TestScalarDetectorsOnly verifies that registered detectors work without the built-in formats.
+func TestScalarDetectorsOnly(t *testing.T) {
+	schema, _ := buildSchema(t, field.Config{
+		ScalarDetectors: []field.ScalarDetector{{
+			Scalar: colorScalar,
+			Detect: func(value string) bool { return strings.HasPrefix(value, "#") },
+		}},
+	}, scalarsTestData)
+	users := schema.Type("usersObject").(*graphql.Object)
+
+	assert.Equal(t, "Color", fieldTypeName(users, "color"))
+	assert.Equal(t, "String", fieldTypeName(users, "seen"), "Built-in formats should be disabled by default")
+}
+
+// TestScalarDetectionMissingKey verifies that strings of a key missing in the first object are detected.
+func TestScalarDetectionMissingKey(t *testing.T) {
+	schema, _ := buildSchema(t, field.Config{Scalars: true}, `{
+        "a": {"event": {"name": "x"}},
+        "b": {"event": {"when": "2024-01-02"}},
+        "c": {"event": {"when": "2024-03-04", "link": "https://example.com"}}
+    }`)
+	event := schema.Type("eventObject").(*graphql.Object)
+
+	assert.Equal(t, "Date", fieldTypeName(event, "when"))
+	assert.Equal(t, "URL", fieldTypeName(event, "link"))
+}
@@ -1,9 +1,14 @@
 package builder
 
 import (
+	"sort"
+
 	"github.com/graphql-go/graphql"
+	"github.com/niklod/json-to-graphql-go/internal/field"
 )
 
+const rootQueryName = "RootQuery"
+
 // GraphQLSchemaBuilder implements SchemaBuilder.
 type GraphQLSchemaBuilder struct {
 	fieldFactory FieldFactory
@@ -23,11 +28,37 @@ func (b *GraphQLSchemaBuilder) BuildSchema(jsonData map[string]interface{}) (*gr
 
 	b.fieldFactory.GatherUnionInfo(jsonData)
 
+	// Keys are visited in order to name colliding types the same way on every build.
+	keys := make([]string, 0, len(jsonData))
+	for key := range jsonData {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	names := b.fieldFactory.FieldNames(rootQueryName, keys)
+
 	fields := graphql.Fields{}
-	for key, value := range jsonData {
-		fields[key] = b.fieldFactory.CreateField(key, value, 0)
+	companions := graphql.Fields{}
+	for _, key := range keys {
+		for name, field := range b.fieldFactory.CreateFields(key, names[key], jsonData[key], 0) {
+			if name == names[key] {
+				fields[name] = field
+			} else {
+				companions[name] = field
+			}
+		}
 	}
 
+	// Companion fields, e.g. the lookup "user" of "users", never shadow fields of the data.
+	for name, field := range companions {
+		if _, exists := fields[name]; !exists {
+			fields[name] = field
+		}
+	}
+
+	b.fieldFactory.AddRelations(jsonData)
+	b.addFederationFields(fields, jsonData)
+
 	// Ensure at least one field exists.
 	if len(fields) == 0 {
 		fields["anyField"] = &graphql.Field{
@@ -40,7 +71,7 @@ func (b *GraphQLSchemaBuilder) BuildSchema(jsonData map[string]interface{}) (*gr
 
 	// Create root query object
 	rootQuery := graphql.NewObject(graphql.ObjectConfig{
-		Name:   "RootQuery",
+		Name:   rootQueryName,
 		Fields: fields,
 	})
 
@@ -49,3 +80,9 @@ func (b *GraphQLSchemaBuilder) BuildSchema(jsonData map[string]interface{}) (*gr
 
 	return &schema, err
 }
+
+// Report describes the adjustments made to the data while building the last schema,
+// e.g. JSON keys renamed to valid GraphQL names.
+func (b *GraphQLSchemaBuilder) Report() field.Report {
+	return b.fieldFactory.Report()
+}
@@ -1,6 +1,7 @@
 package builder
 
 import (
+	"bytes"
 	"encoding/json"
 	"testing"
 
@@ -10,6 +11,28 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+// buildSchema builds the schema of the JSON data decoded with json.Number, as the app does,
+// and returns it with the report of the build.
+func buildSchema(t *testing.T, config field.Config, jsonData string) (*graphql.Schema, field.Report) {
+	t.Helper()
+
+	config.Resolver = resolver.NewJSONResolver([]byte(jsonData))
+	factory, err := field.NewDefaultFieldFactory(config)
+	assert.NoError(t, err, "Factory creation should not error")
+
+	decoder := json.NewDecoder(bytes.NewReader([]byte(jsonData)))
+	decoder.UseNumber()
+
+	var j map[string]interface{}
+	assert.NoError(t, decoder.Decode(&j), "JSON decoding should not error")
+
+	b := NewGraphQLSchemaBuilder(factory)
+	schema, err := b.BuildSchema(j)
+	assert.NoError(t, err, "Schema creation should not error")
+
+	return schema, b.Report()
+}
+
 // TestTierStatsUnion checks that tierStats union info includes lifesteal and ammo.
 func TestTierStatsUnion(t *testing.T) {
 	jsonData := `{
@@ -153,7 +176,7 @@ func TestNestedObjects(t *testing.T) {
 	address, ok := user["address"].(map[string]interface{})
 	assert.True(t, ok, "Address should be a map")
 	assert.Equal(t, "New York", address["city"], "City should be New York")
-	assert.Equal(t, 10001, int(address["zip"].(float64)), "Zip should be 10001")
+	assert.Equal(t, 10001, address["zip"], "Zip should be 10001")
 }
 
 // TestMixedTypesInArray verifies that arrays with mixed types are handled correctly.
@@ -267,7 +290,7 @@ func TestComplexNestedStructures(t *testing.T) {
 	address, ok := user["address"].(map[string]interface{})
 	assert.True(t, ok, "Address should be a map")
 	assert.Equal(t, "New York", address["city"], "City should be New York")
-	assert.Equal(t, 10001, int(address["zip"].(float64)), "Zip should be 10001")
+	assert.Equal(t, 10001, address["zip"], "Zip should be 10001")
 
 	friends, ok := user["friends"].([]interface{})
 	assert.True(t, ok, "Friends should be a list")
@@ -275,11 +298,11 @@ func TestComplexNestedStructures(t *testing.T) {
 
 	friend1 := friends[0].(map[string]interface{})
 	assert.Equal(t, "Alice", friend1["name"], "First friend's name should be Alice")
-	assert.Equal(t, 25, int(friend1["age"].(float64)), "First friend's age should be 25")
+	assert.Equal(t, 25, friend1["age"], "First friend's age should be 25")
 
 	friend2 := friends[1].(map[string]interface{})
 	assert.Equal(t, "Bob", friend2["name"], "Second friend's name should be Bob")
-	assert.Equal(t, 30, int(friend2["age"].(float64)), "Second friend's age should be 30")
+	assert.Equal(t, 30, friend2["age"], "Second friend's age should be 30")
 }
 
 // TestItemStatsUnion verifies that item stats union info includes all possible attributes.
@@ -0,0 +1,305 @@
+package builder
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+)
+
+// builtInScalars are the scalars every schema has, they are not printed.
+var builtInScalars = map[string]bool{
+	"String": true, "Int": true, "Float": true, "Boolean": true, "ID": true,
+}
+
+// sdlOptions adjust the printed SDL, e.g. for the SDL of a federated subgraph.
+type sdlOptions struct {
+	header     string            // printed before the schema, e.g. `extend schema @link(...)`
+	hidden     map[string]bool   // type names and "<type name>.<field>" left out
+	directives map[string]string // type name -> directives applied to the type, e.g. `@key(fields: "id")`
+}
+
+// PrintSchema returns the SDL of the schema, see printSchema.
+// Federation subgraphs print the SDL served by _service.
+func PrintSchema(schema *graphql.Schema) string {
+	if sdl, ok := subgraphSDL(schema); ok {
+		return sdl
+	}
+
+	return printSchema(schema, sdlOptions{})
+}
+
+// printSchema returns the SDL of the schema. Types, fields, arguments and enum values are sorted by name,
+// so the same schema prints the same way every time. Introspection types, built-in scalars and
+// built-in directives are left out.
+func printSchema(schema *graphql.Schema, opts sdlOptions) string {
+	var defs []string
+	if opts.header != "" {
+		defs = append(defs, opts.header)
+	}
+
+	if def := printSchemaDefinition(schema); def != "" {
+		defs = append(defs, def)
+	}
+
+	for _, d := range schema.Directives() {
+		if !isSpecifiedDirective(d) {
+			defs = append(defs, printDirective(d))
+		}
+	}
+
+	typeMap := schema.TypeMap()
+	for _, name := range slices.Sorted(maps.Keys(typeMap)) {
+		if strings.HasPrefix(name, "__") || builtInScalars[name] || opts.hidden[name] {
+			continue
+		}
+
+		defs = append(defs, printType(typeMap[name], opts))
+	}
+
+	return strings.Join(defs, "\n\n") + "\n"
+}
+
+// printSchemaDefinition returns the schema definition, or "" when the root types have the default names.
+func printSchemaDefinition(schema *graphql.Schema) string {
+	roots := []struct {
+		operation, defaultName string
+		typ                    *graphql.Object
+	}{
+		{"query", "Query", schema.QueryType()},
+		{"mutation", "Mutation", schema.MutationType()},
+		{"subscription", "Subscription", schema.SubscriptionType()},
+	}
+
+	custom := false
+	var lines []string
+	for _, root := range roots {
+		if root.typ == nil {
+			continue
+		}
+
+		custom = custom || root.typ.Name() != root.defaultName
+		lines = append(lines, fmt.Sprintf("  %s: %s", root.operation, root.typ.Name()))
+	}
+
+	if !custom {
+		return ""
+	}
+
+	return "schema {\n" + strings.Join(lines, "\n") + "\n}"
+}
+
+func isSpecifiedDirective(d *graphql.Directive) bool {
+	for _, specified := range graphql.SpecifiedDirectives {
+		if d.Name == specified.Name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func printDirective(d *graphql.Directive) string {
+	return printDescription(d.Description, "") +
+		"directive @" + d.Name + printArgs(d.Args, "") + " on " + strings.Join(d.Locations, " | ")
+}
+
+func printType(t graphql.Type, opts sdlOptions) string {
+	var def string
+	switch t := t.(type) {
+	case *graphql.Scalar:
+		def = "scalar " + t.Name()
+	case *graphql.Object:
+		def = "type " + t.Name() + printImplements(t.Interfaces()) + printDirectives(opts.directives[t.Name()]) +
+			printFields(t.Name(), t.Fields(), opts)
+	case *graphql.Interface:
+		def = "interface " + t.Name() + printFields(t.Name(), t.Fields(), opts)
+	case *graphql.Union:
+		def = "union " + t.Name() + " = " + strings.Join(sortedNames(t.Types()), " | ")
+	case *graphql.Enum:
+		def = "enum " + t.Name() + printEnumValues(t.Values())
+	case *graphql.InputObject:
+		def = "input " + t.Name() + printInputFields(t.Fields())
+	}
+
+	return printDescription(t.Description(), "") + def
+}
+
+func printImplements(interfaces []*graphql.Interface) string {
+	if len(interfaces) == 0 {
+		return ""
+	}
+
+	return " implements " + strings.Join(sortedNames(interfaces), " & ")
+}
+
+func printDirectives(directives string) string {
+	if directives == "" {
+		return ""
+	}
+
+	return " " + directives
+}
+
+func printFields(typeName string, fields graphql.FieldDefinitionMap, opts sdlOptions) string {
+	var lines []string
+	for _, name := range slices.Sorted(maps.Keys(fields)) {
+		if opts.hidden[typeName+"."+name] {
+			continue
+		}
+
+		field := fields[name]
+		lines = append(lines, printDescription(field.Description, "  ")+
+			"  "+name+printArgs(field.Args, "  ")+": "+field.Type.String()+printDeprecated(field.DeprecationReason))
+	}
+
+	return printBlock(lines)
+}
+
+// printArgs prints the arguments on one line, or one per line when any has a description.
+func printArgs(args []*graphql.Argument, indent string) string {
+	if len(args) == 0 {
+		return ""
+	}
+
+	sorted := slices.SortedFunc(slices.Values(args), func(a, b *graphql.Argument) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+
+	described := slices.ContainsFunc(sorted, func(arg *graphql.Argument) bool {
+		return arg.Description() != ""
+	})
+
+	printed := make([]string, 0, len(sorted))
+	for _, arg := range sorted {
+		def := arg.Name() + ": " + arg.Type.String() + printDefault(arg.DefaultValue, arg.Type)
+		if described {
+			def = printDescription(arg.Description(), indent+"  ") + indent + "  " + def
+		}
+
+		printed = append(printed, def)
+	}
+
+	if described {
+		return "(\n" + strings.Join(printed, "\n") + "\n" + indent + ")"
+	}
+
+	return "(" + strings.Join(printed, ", ") + ")"
+}
+
+func printInputFields(fields graphql.InputObjectFieldMap) string {
+	var lines []string
+	for _, name := range slices.Sorted(maps.Keys(fields)) {
+		field := fields[name]
+		lines = append(lines, printDescription(field.Description(), "  ")+
+			"  "+name+": "+field.Type.String()+printDefault(field.DefaultValue, field.Type))
+	}
+
+	return printBlock(lines)
+}
+
+func printEnumValues(values []*graphql.EnumValueDefinition) string {
+	sorted := slices.SortedFunc(slices.Values(values), func(a, b *graphql.EnumValueDefinition) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	lines := make([]string, 0, len(sorted))
+	for _, value := range sorted {
+		lines = append(lines, printDescription(value.Description, "  ")+"  "+value.Name+printDeprecated(value.DeprecationReason))
+	}
+
+	return printBlock(lines)
+}
+
+func printBlock(lines []string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+
+	return " {\n" + strings.Join(lines, "\n") + "\n}"
+}
+
+func printDeprecated(reason string) string {
+	if reason == "" {
+		return ""
+	}
+
+	return " @deprecated(reason: " + quote(reason) + ")"
+}
+
+// printDefault returns the default value of an argument or input field, enum values by their name.
+func printDefault(value interface{}, t graphql.Input) string {
+	if value == nil {
+		return ""
+	}
+
+	if enum, ok := t.(*graphql.Enum); ok {
+		for _, v := range enum.Values() {
+			if v.Value == value {
+				return " = " + v.Name
+			}
+		}
+	}
+
+	if s, ok := value.(string); ok {
+		return " = " + quote(s)
+	}
+
+	return fmt.Sprintf(" = %v", value)
+}
+
+// printDescription returns the description followed by a new line, as a block string when it spans lines.
+func printDescription(description, indent string) string {
+	if description == "" {
+		return ""
+	}
+
+	if !strings.Contains(description, "\n") {
+		return indent + quote(description) + "\n"
+	}
+
+	lines := strings.Split(strings.ReplaceAll(description, `"""`, `\"""`), "\n")
+
+	return indent + `"""` + "\n" + indent + strings.Join(lines, "\n"+indent) + "\n" + indent + `"""` + "\n"
+}
+
+// quote returns s as a GraphQL string literal.
+func quote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+
+	for _, r := range s {
+		switch r {
+		case '"', '\\':
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(&b, `\u%04x`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+
+	b.WriteByte('"')
+
+	return b.String()
+}
+
+func sortedNames[T interface{ Name() string }](types []T) []string {
+	names := make([]string, 0, len(types))
+	for _, t := range types {
+		names = append(names, t.Name())
+	}
+
+	return slices.Sorted(slices.Values(names))
+}
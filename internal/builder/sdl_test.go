@@ -0,0 +1,137 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/niklod/json-to-graphql-go/internal/field"
+	"github.com/stretchr/testify/assert"
+)
+
+const sdlTestData = `{
+    "shop": {"name": "Acme", "open": true},
+    "items": [
+        {"id": 1, "name": "Sword", "tier": "rare", "price": 9.5},
+        {"id": 2, "name": "Shield", "tier": "common", "price": 4}
+    ]
+}`
+
+const expectedSDL = `schema {
+  query: RootQuery
+}
+
+input FloatFilter {
+  eq: Float
+  gt: Float
+  gte: Float
+  in: [Float!]
+  lt: Float
+  lte: Float
+  neq: Float
+}
+
+input IDFilter {
+  eq: ID
+  in: [ID!]
+  neq: ID
+}
+
+type RootQuery {
+  item(id: ID!): itemsObject
+  items(
+    "Returns only the elements with the given ids, in the given order."
+    ids: [ID!]
+    "Maximum number of elements to return."
+    limit: Int
+    "Number of elements to skip."
+    offset: Int
+    "Sorts the elements, later entries break ties of earlier ones."
+    orderBy: [itemsObjectOrderBy!]
+    "Returns only the elements matching every condition."
+    where: itemsObjectWhere
+  ): [itemsObject]
+  shop: shopObject
+}
+
+enum SortDirection {
+  ASC
+  DESC
+}
+
+input StringFilter {
+  contains: String
+  eq: String
+  in: [String!]
+  neq: String
+}
+
+type itemsObject {
+  id: ID
+  name: String
+  price: Float
+  tier: String
+}
+
+input itemsObjectOrderBy {
+  direction: SortDirection = ASC
+  field: itemsObjectOrderField!
+}
+
+enum itemsObjectOrderField {
+  id
+  name
+  price
+  tier
+}
+
+input itemsObjectWhere {
+  AND: [itemsObjectWhere!]
+  OR: [itemsObjectWhere!]
+  id: IDFilter
+  name: StringFilter
+  price: FloatFilter
+  tier: StringFilter
+}
+
+type shopObject {
+  name: String
+  open: Boolean
+}
+`
+
+// TestPrintSchema verifies that the SDL is sorted, stable across builds and valid.
+func TestPrintSchema(t *testing.T) {
+	schema, _ := buildSchema(t, field.Config{}, sdlTestData)
+	sdl := PrintSchema(schema)
+	assert.Equal(t, expectedSDL, sdl)
+
+	for range 5 {
+		schema, _ = buildSchema(t, field.Config{}, sdlTestData)
+		assert.Equal(t, sdl, PrintSchema(schema), "SDL should not depend on map order")
+	}
+
+	_, err := parser.Parse(parser.ParseParams{Source: sdl})
+	assert.NoError(t, err, "SDL should parse")
+}
+
+// TestPrintSchemaFeatures verifies that enums, unions, interfaces and non-null types print as valid SDL.
+func TestPrintSchemaFeatures(t *testing.T) {
+	schema, _ := buildSchema(t, field.Config{
+		Enums: true, Unions: true, Interfaces: true, NonNull: true, Scalars: true,
+	}, `{
+        "feed": [
+            {"type": "video", "title": "a", "url": "https://example.com/a"},
+            {"type": "post", "title": "b", "url": "https://example.com/b"}
+        ],
+        "tiers": ["gold", "gold", "silver", "gold", "silver"]
+    }`)
+
+	sdl := PrintSchema(schema)
+	assert.Contains(t, sdl, "union feedObject = feedPostObject | feedVideoObject")
+	assert.Contains(t, sdl, "scalar URL")
+	assert.Contains(t, sdl, "type feedPostObject implements HasTitleTypeUrl {")
+	assert.Contains(t, sdl, "enum Tiers {")
+
+	_, err := parser.Parse(parser.ParseParams{Source: sdl})
+	assert.NoError(t, err, "SDL should parse")
+}
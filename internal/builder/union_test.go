@@ -0,0 +1,121 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/niklod/json-to-graphql-go/internal/field"
+	"github.com/stretchr/testify/assert"
+)
+
+const unionTestData = `{
+    "feed": [
+        {"type": "video", "title": "Intro", "duration": 30, "stream": {"url": "a.mp4"}},
+        {"type": "article", "title": "News", "words": 500},
+        {"type": "video", "title": "Outro", "duration": 12.5}
+    ],
+    "tags": [{"type": "color", "name": "red"}]
+}`
+
+// TestUnionTypes verifies that objects with a discriminator become members of a union.
+func TestUnionTypes(t *testing.T) {
+	schema, _ := buildSchema(t, field.Config{Unions: true}, unionTestData)
+
+	union, ok := schema.Type("feedObject").(*graphql.Union)
+	assert.True(t, ok, "Feed should be a union")
+
+	var members []string
+	for _, member := range union.Types() {
+		members = append(members, member.Name())
+	}
+	assert.Equal(t, []string{"feedArticleObject", "feedVideoObject"}, members)
+
+	video := schema.Type("feedVideoObject").(*graphql.Object)
+	assert.Equal(t, "Float", fieldTypeName(video, "duration"))
+	assert.NotContains(t, video.Fields(), "words", "Members should only have their own fields")
+
+	_, ok = schema.Type("tagsObject").(*graphql.Object)
+	assert.True(t, ok, "A single variant should stay an object")
+
+	result := graphql.Do(graphql.Params{Schema: *schema, RequestString: `{
+        feed(limit: 2) {
+            __typename
+            ... on feedVideoObject { title duration stream { url } }
+            ... on feedArticleObject { title words }
+        }
+    }`})
+	assert.Empty(t, result.Errors, "GraphQL execution should not error")
+
+	expected := map[string]interface{}{
+		"feed": []interface{}{
+			map[string]interface{}{
+				"__typename": "feedVideoObject",
+				"title":      "Intro",
+				"duration":   30.0,
+				"stream":     map[string]interface{}{"url": "a.mp4"},
+			},
+			map[string]interface{}{"__typename": "feedArticleObject", "title": "News", "words": 500},
+		},
+	}
+	assert.Equal(t, expected, result.Data)
+}
+
+// TestUnionDiscriminators verifies the configured discriminator keys and path naming of members.
+func TestUnionDiscriminators(t *testing.T) {
+	schema, _ := buildSchema(t, field.Config{Unions: true, Discriminators: []string{"kind"}, Naming: field.NamingByPath}, `{
+        "shapes": [{"kind": "circle", "r": 1}, {"kind": "square", "side": 2}],
+        "feed": [{"type": "video"}, {"type": "article"}]
+    }`)
+
+	union, ok := schema.Type("Shapes").(*graphql.Union)
+	assert.True(t, ok, "Shapes should be a union")
+	assert.Len(t, union.Types(), 2)
+	assert.NotNil(t, schema.Type("ShapesCircle"))
+
+	_, ok = schema.Type("Feed").(*graphql.Object)
+	assert.True(t, ok, "Keys that are not configured should not discriminate")
+}
+
+// TestUnionSharedKey verifies that lists sharing a type key get one union with the variants of all of them.
+func TestUnionSharedKey(t *testing.T) {
+	schema, _ := buildSchema(t, field.Config{Unions: true}, `{
+        "home": {"feed": [{"type": "video", "duration": 30}, {"type": "article", "words": 500}]},
+        "profile": {"feed": [{"type": "photo", "url": "a.png"}, {"type": "video", "duration": 12}]}
+    }`)
+
+	union := schema.Type("feedObject").(*graphql.Union)
+
+	var members []string
+	for _, member := range union.Types() {
+		members = append(members, member.Name())
+	}
+	assert.Equal(t, []string{"feedArticleObject", "feedPhotoObject", "feedVideoObject"}, members)
+
+	result := graphql.Do(graphql.Params{Schema: *schema, RequestString: `{
+        home { feed { __typename } }
+        profile { feed { __typename ... on feedPhotoObject { url } } }
+    }`})
+	assert.Empty(t, result.Errors, "GraphQL execution should not error")
+
+	expected := map[string]interface{}{
+		"home": map[string]interface{}{"feed": []interface{}{
+			map[string]interface{}{"__typename": "feedVideoObject"},
+			map[string]interface{}{"__typename": "feedArticleObject"},
+		}},
+		"profile": map[string]interface{}{"feed": []interface{}{
+			map[string]interface{}{"__typename": "feedPhotoObject", "url": "a.png"},
+			map[string]interface{}{"__typename": "feedVideoObject"},
+		}},
+	}
+	assert.Equal(t, expected, result.Data)
+}
+
+// TestUnionsDisabled verifies that objects are merged by default.
+func TestUnionsDisabled(t *testing.T) {
+	schema, _ := buildSchema(t, field.Config{}, unionTestData)
+
+	feed, ok := schema.Type("feedObject").(*graphql.Object)
+	assert.True(t, ok, "Feed should be an object")
+	assert.Contains(t, feed.Fields(), "words")
+	assert.Contains(t, feed.Fields(), "duration")
+}
@@ -0,0 +1,112 @@
+package field
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/graphql-go/graphql"
+)
+
+// defaultDiscriminators are the keys checked for the variant of an object when Config.Discriminators is empty.
+var defaultDiscriminators = []string{"__typename", "type", "kind"}
+
+// variantSeparator marks the variant in the type key of a union member, e.g. "feed.#video".
+const variantSeparator = "#"
+
+// discriminator returns the key telling apart the variants of the objects in the list, e.g. for
+//
+//	[{"type": "video", "duration": 30}, {"type": "article", "words": 500}]
+//
+// it returns "type". The first configured key is picked that holds a string in every object
+// with at least two different values. It returns "" when unions are disabled or no key matches.
+func (f *DefaultFieldFactory) discriminator(arr []interface{}) string {
+	if !f.unions {
+		return ""
+	}
+
+	ok, objects := allMaps(arr)
+	if !ok || len(objects) < 2 {
+		return ""
+	}
+
+	for _, key := range f.discriminators {
+		values := make(map[string]bool)
+
+		for _, obj := range objects {
+			value, ok := obj[key].(string)
+			if !ok || value == "" {
+				values = nil
+
+				break
+			}
+
+			values[value] = true
+		}
+
+		if len(values) > 1 {
+			return key
+		}
+	}
+
+	return ""
+}
+
+// variantKey returns the type key of a union member, the variant of an object of the list under the type key.
+func variantKey(typeKey, discriminator string, obj map[string]interface{}) string {
+	variant, _ := obj[discriminator].(string)
+
+	return typeKey + pathSeparator + variantSeparator + escapeKey(variant)
+}
+
+// createUnionListField returns a list of a union with a member type per variant of the objects.
+func (f *DefaultFieldFactory) createUnionListField(parent, key, slot, discriminator string, arr []interface{}, depth int) *graphql.Field {
+	typeKey := f.childKey(parent, key)
+	typeName := f.typeName(typeKey)
+
+	// Lists sharing the type key, e.g. with NamingByKey, share the union of all their variants.
+	union, ok := f.unionTypesCache.get(typeKey)
+	if !ok {
+		union = f.createUnion(typeName, typeKey, discriminator, arr, depth)
+		f.unionTypesCache.set(typeKey, union)
+	}
+
+	return &graphql.Field{
+		Type:    graphql.NewList(f.elementType(parent, slot+elementSuffix, union)),
+		Args:    f.listArgs(union),
+		Resolve: f.resolver.ResolveArrayValue,
+	}
+}
+
+// createUnion creates a member type per variant of the objects under the type key, see GatherUnionInfo.
+// The type of an element is resolved by the value of its discriminator.
+func (f *DefaultFieldFactory) createUnion(typeName, typeKey, discriminator string, arr []interface{}, depth int) *graphql.Union {
+	if _, gathered := f.variantInfo[typeKey]; !gathered {
+		for _, item := range arr {
+			obj := item.(map[string]interface{})
+			f.variantInfo.observe(typeKey, obj[discriminator].(string), obj)
+		}
+	}
+
+	variants := f.variantInfo[typeKey]
+	members := make(map[string]*graphql.Object, len(variants))
+	types := make([]*graphql.Object, 0, len(variants))
+
+	for _, variant := range slices.Sorted(maps.Keys(variants)) {
+		merged := variants[variant]
+		member := f.createObjectField(variantKey(typeKey, discriminator, merged), merged, depth+1)
+
+		obj := member.Type.(*graphql.Object)
+		members[variant] = obj
+		types = append(types, obj)
+	}
+
+	return graphql.NewUnion(graphql.UnionConfig{
+		Name:  typeName,
+		Types: types,
+		ResolveType: func(p graphql.ResolveTypeParams) *graphql.Object {
+			variant, _ := f.resolver.Lookup(p.Value, discriminator).(string)
+
+			return members[variant]
+		},
+	})
+}
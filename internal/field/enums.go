@@ -0,0 +1,134 @@
+package field
+
+import (
+	"slices"
+	"strconv"
+
+	"github.com/graphql-go/graphql"
+)
+
+const (
+	defaultEnumMaxValues  = 10
+	defaultEnumMinSamples = 4
+)
+
+// stringStats are the strings observed for a key, see gatherValue.
+type stringStats struct {
+	values   map[string]bool
+	samples  int
+	overflow bool // more distinct values than an enum may have, values are no longer tracked
+	enum     *graphql.Enum
+	rejected []bool // detectors rejecting at least one value, see scalarType
+}
+
+type stringMap map[string]map[string]*stringStats // type key -> subfield -> observed strings
+
+func (s stringMap) get(key, subKey string) *stringStats {
+	if s[key] == nil {
+		s[key] = make(map[string]*stringStats)
+	}
+
+	if s[key][subKey] == nil {
+		s[key][subKey] = &stringStats{values: make(map[string]bool)}
+	}
+
+	return s[key][subKey]
+}
+
+func (s stringMap) reset() {
+	clear(s)
+}
+
+// fieldPath returns the path of the key of the parent object as used by Conflict.Path,
+// EnumAllow and EnumDeny, e.g. "items.tier".
+func fieldPath(parent, key string) string {
+	if parent == rootKey {
+		return escapeKey(key)
+	}
+
+	return parent + pathSeparator + escapeKey(key)
+}
+
+// observeString records a string value of the key when enum inference or scalar detection is enabled.
+func (f *DefaultFieldFactory) observeString(parent, key, value string) {
+	if !f.enums && len(f.scalarDetectors) == 0 {
+		return
+	}
+
+	stats := f.stringInfo.get(parent, key)
+	stats.samples++
+	f.observeFormats(stats, value)
+
+	if !f.enums || stats.overflow {
+		return
+	}
+
+	stats.values[value] = true
+
+	if len(stats.values) > f.enumMaxValues && !f.enumAllow[fieldPath(parent, key)] {
+		stats.overflow = true
+		stats.values = nil
+	}
+}
+
+// enumType returns the enum generated for the strings of the key, or nil if they aren't an enum.
+// Strings become an enum when every value is a valid GraphQL name and
+//   - the path is in EnumAllow, or
+//   - the path isn't in EnumDeny, there are at least EnumMinSamples values, at most
+//     EnumMaxValues distinct ones and every distinct value occurs twice on average.
+func (f *DefaultFieldFactory) enumType(parent, key string) *graphql.Enum {
+	if !f.enums {
+		return nil
+	}
+
+	stats := f.stringInfo.get(parent, key)
+	if stats.enum != nil {
+		return stats.enum
+	}
+
+	path := fieldPath(parent, key)
+	if stats.overflow || len(stats.values) == 0 || f.enumDeny[path] {
+		return nil
+	}
+
+	if !f.enumAllow[path] && (stats.samples < f.enumMinSamples || len(stats.values)*2 > stats.samples) {
+		return nil
+	}
+
+	values := graphql.EnumValueConfigMap{}
+	for value := range stats.values {
+		if !isValidName(value) || value == "true" || value == "false" || value == "null" {
+			return nil
+		}
+
+		values[value] = &graphql.EnumValueConfig{Value: value}
+	}
+
+	stats.enum = graphql.NewEnum(graphql.EnumConfig{
+		Name:   f.enumName(parent, key),
+		Values: values,
+	})
+	f.enumTypesCache.set(stats.enum.Name(), stats.enum)
+
+	return stats.enum
+}
+
+// enumName names the enum by the path of the key, e.g. "items.tier" -> "ItemsTier".
+func (f *DefaultFieldFactory) enumName(parent, key string) string {
+	var path []string
+	if parent != rootKey {
+		path = append(path, splitTypeKey(parent)...)
+	}
+
+	base := sanitizeName(f.pathNameFn(append(path, key)))
+
+	name := base
+	for i := 2; f.typeExists(name) || slices.Contains(reservedEnumNames, name); i++ {
+		name = base + strconv.Itoa(i)
+	}
+
+	return name
+}
+
+// reservedEnumNames are enums defined by the factory itself.
+var reservedEnumNames = []string{"SortDirection"}
@@ -2,4 +2,7 @@ package field
 
 import "errors"
 
-var ErrResolverNotProvided = errors.New("resolver not provided")
+var (
+	ErrResolverNotProvided = errors.New("resolver not provided")
+	ErrUnknownEntity       = errors.New("unknown entity type")
+)
@@ -1,22 +1,106 @@
 package field
 
 import (
+	"encoding/json"
+	"slices"
 	"sort"
 
 	"github.com/graphql-go/graphql"
 )
 
+// rootKey is the bare key of the root object.
+const rootKey = ""
+
 type Config struct {
 	GQLObjectNamingFn func(key string) string
+	GQLPathNamingFn   func(path []string) string
 	Resolver          Resolver
+	// Naming selects how objects are grouped into types, NamingByKey by default.
+	Naming NamingStrategy
+	// Connections adds a Relay style "<key>Connection" field next to every list of objects.
+	Connections bool
+	// Unions turns lists of objects with a discriminator into lists of a union with a type per variant.
+	Unions bool
+	// Discriminators are the keys checked for the variant of an object, in order.
+	// Defaults to "__typename", "type" and "kind".
+	Discriminators []string
+	// Interfaces extracts GraphQL interfaces from the fields shared by object types.
+	Interfaces bool
+	// InterfaceMinFields is the least number of shared fields making an interface, 2 by default.
+	InterfaceMinFields int
+	// GQLInterfaceNamingFn names an interface by its sorted field names.
+	GQLInterfaceNamingFn func(fields []string) string
+	// Enums turns string fields with few distinct values into enums.
+	Enums bool
+	// EnumMaxValues is the most distinct values of an enum, 10 by default.
+	EnumMaxValues int
+	// EnumMinSamples is the least number of observed values of an enum, 4 by default.
+	EnumMinSamples int
+	// EnumAllow lists paths, e.g. "items.tier", that become enums regardless of the thresholds.
+	EnumAllow []string
+	// EnumDeny lists paths that never become enums.
+	EnumDeny []string
+	// NonNull marks fields present and not null in every observed object as non-null,
+	// and the elements of lists without null elements.
+	NonNull bool
+	// Scalars types strings as DateTime, Date, UUID, Email or URL when every value has the format.
+	Scalars bool
+	// ScalarDetectors are checked before the built-in formats, even when Scalars is disabled.
+	ScalarDetectors []ScalarDetector
+	// InferRelations links top-level lists of objects by keys like "userId" holding ids of another list, see Relation.
+	InferRelations bool
+	// Relations are added regardless of InferRelations and replace inferred relations of the same keys.
+	Relations []Relation
+	// Federation makes the schema a Federation v2 subgraph whose entities are the top-level lists
+	// of objects with an id, see Entity.
+	Federation bool
+	// EntityKeys maps top-level lists to the key identifying their objects, e.g. "products": "sku",
+	// for lists without an id.
+	EntityKeys map[string]string
 }
 
 // DefaultFieldFactory is the default implementation.
 type DefaultFieldFactory struct {
-	unionInfo     unionMap
-	gqlTypesCache gqlTypesCache
-	resolver      Resolver
-	objectNameFn  func(key string) string
+	unionInfo       unionMap
+	variantInfo     variantMap
+	numberInfo      numberMap
+	kindInfo        kindMap
+	presenceInfo    presenceMap
+	gqlTypesCache   gqlTypesCache
+	inputTypesCache inputTypesCache
+	enumTypesCache  enumTypesCache
+	unionTypesCache unionTypesCache
+	resolver        Resolver
+	objectNameFn    func(key string) string
+	pathNameFn      func(path []string) string
+	naming          NamingStrategy
+	typeNames       map[string]string // type key -> GraphQL type name
+	typeKeys        map[string]string // GraphQL type name -> type key, with NamingByKey, see typeName
+	fieldKeys       fieldKeys
+	report          Report
+	connections     bool
+	unions          bool
+	discriminators  []string
+	nonNull         bool
+	scalarDetectors []ScalarDetector
+	inferRelations  bool
+	relations       []Relation
+	relationFields  map[string]bool // "<type name>.<field>" of fields added by relations
+	federation      bool
+	entityKeys      map[string]string
+
+	interfacesEnabled  bool
+	interfaceMinFields int
+	interfaceNameFn    func(fields []string) string
+	objectTypes        []*graphql.Object
+	interfaces         map[string][]*graphql.Interface // object type name -> implemented interfaces
+
+	enums          bool
+	enumMaxValues  int
+	enumMinSamples int
+	enumAllow      map[string]bool
+	enumDeny       map[string]bool
+	stringInfo     stringMap
 }
 
 // NewDefaultFieldFactory creates a new DefaultFieldFactory.
@@ -29,40 +113,152 @@ func NewDefaultFieldFactory(config Config) (*DefaultFieldFactory, error) {
 		config.GQLObjectNamingFn = defaultObjectNamingFunciton
 	}
 
+	if len(config.Discriminators) == 0 {
+		config.Discriminators = defaultDiscriminators
+	}
+
+	if config.InterfaceMinFields <= 0 {
+		config.InterfaceMinFields = defaultInterfaceMinFields
+	}
+
+	if config.GQLInterfaceNamingFn == nil {
+		config.GQLInterfaceNamingFn = defaultInterfaceNamingFunction
+	}
+
+	if config.EnumMaxValues <= 0 {
+		config.EnumMaxValues = defaultEnumMaxValues
+	}
+
+	if config.EnumMinSamples <= 0 {
+		config.EnumMinSamples = defaultEnumMinSamples
+	}
+
+	scalarDetectors := config.ScalarDetectors
+	if config.Scalars {
+		scalarDetectors = append(slices.Clip(scalarDetectors), defaultScalarDetectors...)
+	}
+
+	if config.GQLPathNamingFn == nil {
+		config.GQLPathNamingFn = defaultPathNamingFunction
+	}
+
 	return &DefaultFieldFactory{
-		unionInfo:     make(unionMap),
-		gqlTypesCache: make(gqlTypesCache),
-		objectNameFn:  config.GQLObjectNamingFn,
-		resolver:      config.Resolver,
+		unionInfo:       make(unionMap),
+		variantInfo:     make(variantMap),
+		numberInfo:      make(numberMap),
+		kindInfo:        make(kindMap),
+		presenceInfo:    newPresenceMap(),
+		gqlTypesCache:   make(gqlTypesCache),
+		inputTypesCache: make(inputTypesCache),
+		enumTypesCache:  make(enumTypesCache),
+		unionTypesCache: make(unionTypesCache),
+		objectNameFn:    config.GQLObjectNamingFn,
+		pathNameFn:      config.GQLPathNamingFn,
+		naming:          config.Naming,
+		typeNames:       make(map[string]string),
+		typeKeys:        make(map[string]string),
+		fieldKeys:       make(fieldKeys),
+		resolver:        config.Resolver,
+		connections:     config.Connections,
+		unions:          config.Unions,
+		discriminators:  config.Discriminators,
+		nonNull:         config.NonNull,
+		scalarDetectors: scalarDetectors,
+		inferRelations:  config.InferRelations,
+		relations:       config.Relations,
+		relationFields:  make(map[string]bool),
+		federation:      config.Federation,
+		entityKeys:      config.EntityKeys,
+
+		interfacesEnabled:  config.Interfaces,
+		interfaceMinFields: config.InterfaceMinFields,
+		interfaceNameFn:    config.GQLInterfaceNamingFn,
+
+		enums:          config.Enums,
+		enumMaxValues:  config.EnumMaxValues,
+		enumMinSamples: config.EnumMinSamples,
+		enumAllow:      pathSet(config.EnumAllow),
+		enumDeny:       pathSet(config.EnumDeny),
+		stringInfo:     make(stringMap),
 	}, nil
 }
 
 // CreateField dispatches field creation based on the type of JSON value.
+// The key is treated as a field of the root object.
 func (f *DefaultFieldFactory) CreateField(key string, value interface{}, depth int) *graphql.Field {
+	return f.createField(rootKey, key, value, depth)
+}
+
+// CreateFields returns the field named name for the given key together with its companion fields,
+// e.g. the connection of a list when connections are enabled or the lookup of an object of a list by id.
+// See FieldNames for valid names.
+func (f *DefaultFieldFactory) CreateFields(key, name string, value interface{}, depth int) graphql.Fields {
+	return f.createFields(rootKey, key, name, value, depth)
+}
+
+// createField creates the field for the key of the object stored under the parent key.
+func (f *DefaultFieldFactory) createField(parent, key string, value interface{}, depth int) *graphql.Field {
 	if depth > 10 {
 		return &graphql.Field{Type: graphql.String}
 	}
 
+	// Values of different types, e.g. sometimes an object and sometimes a string, are returned as is.
+	if f.kindInfo.conflicting(parent, escapeKey(key)) {
+		return f.createJSONField()
+	}
+
 	switch v := value.(type) {
-	case string, float64, bool, nil:
-		return f.createScalarField(v)
+	case string, float64, json.Number, bool, nil:
+		return f.createScalarField(parent, key, v)
 	case map[string]interface{}:
-		return f.createObjectField(key, v, depth)
+		return f.createObjectField(f.childKey(parent, key), v, depth)
 	case []interface{}:
-		return f.createListField(key, v, depth)
+		return f.createListField(parent, key, escapeKey(key), v, depth)
 	default:
 		return &graphql.Field{Type: graphql.String}
 	}
 }
 
+func (f *DefaultFieldFactory) createFields(parent, key, name string, value interface{}, depth int) graphql.Fields {
+	field := f.createField(parent, key, value, depth)
+
+	var lookup *graphql.Field
+	if parent == rootKey {
+		lookup = f.addLookups(field, key, value)
+	}
+
+	if f.nonNull && f.presenceInfo.required(parent, escapeKey(key)) {
+		field.Type = graphql.NewNonNull(field.Type)
+	}
+
+	if name != key && field.Resolve != nil {
+		field.Resolve = resolveKey(key, field.Resolve)
+	}
+
+	fields := graphql.Fields{name: field}
+
+	if lookup != nil {
+		lookup.Resolve = resolveKey(key, lookup.Resolve)
+		fields[lookupName(name)] = lookup
+	}
+
+	if f.connections {
+		if connection := f.connectionField(key, field); connection != nil {
+			fields[name+"Connection"] = connection
+		}
+	}
+
+	return fields
+}
+
 // mergeKeys merges keys from the current object and union info.
-func (f *DefaultFieldFactory) mergeKeys(key string, m map[string]interface{}) []string {
+func (f *DefaultFieldFactory) mergeKeys(typeKey string, m map[string]interface{}) []string {
 	keysSet := make(map[string]bool)
 	for k := range m {
 		keysSet[k] = true
 	}
 
-	if info, exists := f.unionInfo[key]; exists {
+	if info, exists := f.unionInfo[typeKey]; exists {
 		for subKey := range info {
 			keysSet[subKey] = true
 		}
@@ -80,7 +276,23 @@ func (f *DefaultFieldFactory) mergeKeys(key string, m map[string]interface{}) []
 
 func (f *DefaultFieldFactory) ResetCache() {
 	f.gqlTypesCache.reset()
+	f.inputTypesCache.reset()
+	f.enumTypesCache.reset()
+	f.unionTypesCache.reset()
 	f.unionInfo.reset()
+	f.variantInfo.reset()
+	f.numberInfo.reset()
+	f.kindInfo.reset()
+	f.presenceInfo.reset()
+	f.stringInfo.reset()
+	clear(f.typeNames)
+	clear(f.typeKeys)
+	// Resolvers of the previous schema still read the old keys, so they are replaced instead of cleared.
+	f.fieldKeys = make(fieldKeys)
+	f.report = Report{}
+	clear(f.relationFields)
+	f.objectTypes = nil
+	f.interfaces = nil
 }
 
 // allMaps returns true if every element in the array is a map.
@@ -98,3 +310,12 @@ func allMaps(arr []interface{}) (bool, []map[string]interface{}) {
 	}
 	return true, res
 }
+
+func pathSet(paths []string) map[string]bool {
+	set := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		set[path] = true
+	}
+
+	return set
+}
@@ -6,24 +6,43 @@ import (
 
 // createListField function is responsible for creating a GraphQL field that represents an array (graphql.List).
 // Its main task is to correctly determine the type of array elements, even if the JSON contains different data structures within the same list.
-func (f *DefaultFieldFactory) createListField(key string, arr []interface{}, depth int) *graphql.Field {
+// The slot is the escaped key with an elementSuffix per level of nested lists, see gatherValue.
+func (f *DefaultFieldFactory) createListField(parent, key, slot string, arr []interface{}, depth int) *graphql.Field {
 	if len(arr) == 0 {
 		return &graphql.Field{Type: graphql.NewList(graphql.String)}
 	}
 
+	// Objects of different variants become a list of a union, when enabled.
+	if discriminator := f.discriminator(arr); discriminator != "" {
+		return f.createUnionListField(parent, key, slot, discriminator, arr, depth)
+	}
+
 	// If every element in the array is a map (array of json objects), we run a special case.
 	// In this case we merge all maps and create a field for the merged map.
 	if ok, arrMaps := allMaps(arr); ok {
-		return f.createListFieldForMaps(key, arrMaps, depth)
+		return f.createListFieldForMaps(parent, key, slot, arrMaps, depth)
 	}
 
 	// Create a field for the first element in the array.
 	// For code simplicity we assume that all elements in the array have the same type.
-	elementField := f.CreateField(key, arr[0], depth+1)
+	// Numbers are the exception: Int or Float is decided by every element of the array,
+	// and elements of different types are returned as is.
+	var elementField *graphql.Field
+	elementSlot := slot + elementSuffix
+
+	switch element, isList := arr[0].([]interface{}); {
+	case f.kindInfo.conflicting(parent, elementSlot):
+		elementField = f.createJSONField()
+	case isList:
+		elementField = f.createListField(parent, key, elementSlot, element, depth+1)
+	default:
+		elementField = f.createField(parent, key, arr[0], depth+1)
+	}
 
 	return &graphql.Field{
-		Type:    graphql.NewList(elementField.Type),
-		Resolve: f.resolver.ResolveArrayValue,
+		Type:    graphql.NewList(f.elementType(parent, elementSlot, elementField.Type)),
+		Args:    f.listArgs(elementField.Type),
+		Resolve: f.resolveWhereKeys(elementField.Type, f.resolver.ResolveArrayValue),
 	}
 }
 
@@ -33,13 +52,24 @@ func (f *DefaultFieldFactory) createListField(key string, arr []interface{}, dep
 //   - Identifies all possible fields that may appear.
 //   - Creates a unified GraphQL type that includes the merged schema of all possible fields.
 //   - Returns a GraphQL list of objects (graphql.List).
-func (f *DefaultFieldFactory) createListFieldForMaps(key string, arrObjects []map[string]interface{}, depth int) *graphql.Field {
+func (f *DefaultFieldFactory) createListFieldForMaps(parent, key, slot string, arrObjects []map[string]interface{}, depth int) *graphql.Field {
 	mergedDefaults := mergeMaps(arrObjects)
-	mergedField := f.CreateField(key, mergedDefaults, depth+1)
-	listType := graphql.NewList(mergedField.Type)
+	mergedField := f.createField(parent, key, mergedDefaults, depth+1)
+	listType := graphql.NewList(f.elementType(parent, slot+elementSuffix, mergedField.Type))
 
 	return &graphql.Field{
 		Type:    listType,
-		Resolve: f.resolver.ResolveArrayValue,
+		Args:    f.listArgs(mergedField.Type),
+		Resolve: f.resolveWhereKeys(mergedField.Type, f.resolver.ResolveArrayValue),
 	}
 }
+
+// elementType returns the type of the elements of a list, non-null when enabled and no element
+// recorded for the slot was null.
+func (f *DefaultFieldFactory) elementType(parent, slot string, t graphql.Output) graphql.Output {
+	if f.nonNull && f.presenceInfo.elementsRequired(parent, slot) {
+		return graphql.NewNonNull(t)
+	}
+
+	return t
+}
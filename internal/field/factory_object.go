@@ -1,6 +1,10 @@
 package field
 
 import (
+	"maps"
+	"slices"
+	"strconv"
+
 	"github.com/graphql-go/graphql"
 )
 
@@ -11,21 +15,53 @@ import (
 //   - Iterates over all discovered keys to create corresponding GraphQL fields.
 //   - Stores the generated type in the cache for future use.
 //   - Defines a Resolve function that dynamically fetches JSON data at runtime.
-func (f *DefaultFieldFactory) createObjectField(key string, m map[string]interface{}, depth int) *graphql.Field {
-	typeName := f.objectNameFn(key)
-	if cached, ok := f.gqlTypesCache.get(typeName); ok {
-		return &graphql.Field{Type: cached}
+//
+// The type key groups the objects sharing a type, see NamingStrategy.
+func (f *DefaultFieldFactory) createObjectField(typeKey string, m map[string]interface{}, depth int) *graphql.Field {
+	typeName, built := f.typeNames[typeKey]
+	if !built {
+		typeName = f.typeName(typeKey)
+	}
+
+	// By key every object with the same name shares the type, by path only the objects of the same path do.
+	if cached, ok := f.gqlTypesCache.get(typeName); ok && (built || f.naming == NamingByKey) {
+		return &graphql.Field{
+			Type:    cached,
+			Resolve: f.resolver.ResolveObjectValue,
+		}
 	}
 
 	// Build fields separately
-	fields := f.buildGraphQLFields(key, m, depth)
+	names := fieldNames(f.mergeKeys(typeKey, m))
+	fields := f.buildGraphQLFields(typeKey, m, names, depth)
+	keys := renamedKeys(names)
+
+	var objType *graphql.Object
+	if f.naming == NamingByPath {
+		objType, typeName = f.compatibleType(typeName, fields, keys)
+	}
+
+	if objType == nil {
+		config := graphql.ObjectConfig{
+			Name:   typeName,
+			Fields: fields,
+		}
+		if f.interfacesEnabled {
+			config.Interfaces = f.objectInterfaces(typeName)
+		}
+
+		objType = graphql.NewObject(config)
+
+		f.gqlTypesCache.set(typeName, objType)
+		f.objectTypes = append(f.objectTypes, objType)
+		f.reportRenames(typeName, names)
 
-	objType := graphql.NewObject(graphql.ObjectConfig{
-		Name:   typeName,
-		Fields: fields,
-	})
+		if len(keys) > 0 {
+			f.fieldKeys[typeName] = keys
+		}
+	}
 
-	f.gqlTypesCache.set(typeName, objType)
+	f.typeNames[typeKey] = typeName
 
 	return &graphql.Field{
 		Type:    objType,
@@ -40,11 +76,13 @@ func (f *DefaultFieldFactory) createObjectField(key string, m map[string]interfa
 // - Merging all possible keys from the given object (`inputObj`) and `unionInfo`.
 // - Ensuring that missing fields are either set to `nil` (to prevent schema mismatches) or processed correctly.
 // - Recursively creating fields for nested structures.
-func (f *DefaultFieldFactory) buildGraphQLFields(key string, inputObj map[string]interface{}, depth int) graphql.Fields {
-	keys := f.mergeKeys(key, inputObj)
+//
+// Fields are named by names, a map of every key to a valid GraphQL name, see fieldNames.
+func (f *DefaultFieldFactory) buildGraphQLFields(typeKey string, inputObj map[string]interface{}, names map[string]string, depth int) graphql.Fields {
 	fields := graphql.Fields{}
 
-	for _, k := range keys {
+	for _, k := range slices.Sorted(maps.Keys(names)) {
+		name := names[k]
 		var subVal interface{}
 
 		// Assign value if key exists in the current object
@@ -53,12 +91,62 @@ func (f *DefaultFieldFactory) buildGraphQLFields(key string, inputObj map[string
 		}
 
 		// If key is in unionInfo but missing in inputObj, set subVal to nil
-		if subVal == nil && f.unionInfo.subkeyExists(key, k) {
+		if subVal == nil && f.unionInfo.subkeyExists(typeKey, k) {
 			subVal = nil
 		}
 
-		fields[k] = f.CreateField(k, subVal, depth+1)
+		for name, field := range f.createFields(typeKey, k, name, subVal, depth+1) {
+			fields[name] = field
+		}
 	}
 
 	return fields
 }
+
+// compatibleType resolves collisions of type names created from different paths.
+// It returns the existing type when it has the same shape as the fields and reads the same JSON keys,
+// otherwise nil and the name with a numeric suffix that is still free, e.g. "UserAddress2".
+func (f *DefaultFieldFactory) compatibleType(typeName string, fields graphql.Fields, keys map[string]string) (*graphql.Object, string) {
+	name := typeName
+	for i := 2; ; i++ {
+		existing, ok := f.gqlTypesCache.get(name)
+		if !ok {
+			return nil, name
+		}
+
+		if sameShape(existing, fields) && maps.Equal(f.fieldKeys[name], keys) {
+			return existing, name
+		}
+
+		name = typeName + strconv.Itoa(i)
+	}
+}
+
+// sameShape reports whether the object has exactly the given fields with the same types.
+func sameShape(obj *graphql.Object, fields graphql.Fields) bool {
+	existing := obj.Fields()
+	if len(existing) != len(fields) {
+		return false
+	}
+
+	for name, field := range fields {
+		def, ok := existing[name]
+		if !ok || def.Type.String() != field.Type.String() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// renamedKeys inverts the names of the keys that were renamed, field name -> JSON key.
+func renamedKeys(names map[string]string) map[string]string {
+	keys := make(map[string]string)
+	for key, name := range names {
+		if key != name {
+			keys[name] = key
+		}
+	}
+
+	return keys
+}
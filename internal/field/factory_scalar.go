@@ -1,18 +1,40 @@
 package field
 
-import (
-	"github.com/graphql-go/graphql"
-)
+import "github.com/graphql-go/graphql"
 
 // createScalarField returns a field for scalar values.
-func (f *DefaultFieldFactory) createScalarField(value interface{}) *graphql.Field {
+// Fields named "id" are IDs.
+// Numbers are Int when every value observed for the key of the parent object is an Int, Float otherwise.
+// Strings are a detected scalar, e.g. DateTime, or an enum when enabled and their values qualify,
+// see scalarType and enumType.
+//
+// A null or missing value, e.g. of a key that is missing in the object the type is built from,
+// is typed by the values observed for the key in the other objects.
+func (f *DefaultFieldFactory) createScalarField(parent, key string, value interface{}) *graphql.Field {
+	kind := kindOf(value)
+	if kind == 0 {
+		kind = f.kindInfo.kind(parent, escapeKey(key))
+	}
+
 	var t graphql.Output
-	switch value.(type) {
-	case string:
+	switch kind {
+	case kindString:
 		t = graphql.String
-	case float64:
+		if key == idKey {
+			t = graphql.ID
+		} else if scalar := f.scalarType(parent, key); scalar != nil {
+			t = scalar
+		} else if enum := f.enumType(parent, key); enum != nil {
+			t = enum
+		}
+	case kindNumber:
 		t = graphql.Float
-	case bool:
+		if key == idKey {
+			t = graphql.ID
+		} else if isInt, observed := f.numberInfo.isInt(parent, key); isInt || !observed && numberIsInt(value) {
+			t = graphql.Int
+		}
+	case kindBool:
 		t = graphql.Boolean
 	default:
 		t = graphql.String
@@ -0,0 +1,210 @@
+package field
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/graphql-go/graphql"
+)
+
+// Entity is the object type of a top-level list that a federated gateway resolves by key,
+// see Config.Federation.
+type Entity struct {
+	Type *graphql.Object
+	// Key is the field identifying the objects, e.g. "id".
+	Key string
+
+	list  string         // key of the top-level list
+	idKey string         // JSON key of Key
+	index map[string]int // id -> position in the list
+}
+
+// anyScalar is the Federation _Any scalar of the representations of entities, e.g. {__typename: "User", id: "1"}.
+var anyScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "_Any",
+	Description: "The representation of an entity, its __typename and key.",
+	Serialize:   serializeJSON,
+	ParseValue: func(value interface{}) interface{} {
+		return value
+	},
+	ParseLiteral: parseJSONLiteral,
+})
+
+// RejectedEntityKey is a key of Config.EntityKeys that identifies no entity, e.g. because its list doesn't exist.
+type RejectedEntityKey struct {
+	List   string // key of the top-level list
+	Key    string // configured key of its objects
+	Reason string
+}
+
+// Entities returns the entities of the data sorted by type name. The objects of a top-level list are an entity
+// when they have a unique id, see idIndex, or the unique key configured in Config.EntityKeys.
+// Configured keys that identify no entity are reported, see Report.RejectedEntityKeys.
+// It returns false when federation is disabled.
+func (f *DefaultFieldFactory) Entities(data map[string]interface{}) ([]Entity, bool) {
+	if !f.federation {
+		return nil, false
+	}
+
+	collections := collectionsOf(data)
+	seen := make(map[string]bool)
+
+	for _, key := range slices.Sorted(maps.Keys(f.entityKeys)) {
+		if collections[key] == nil {
+			f.rejectEntityKey(key, strconv.Quote(key)+" is not a top-level list of objects")
+		}
+	}
+
+	var res []Entity
+	for _, key := range slices.Sorted(maps.Keys(collections)) {
+		c := collections[key]
+
+		idKey, index := c.idKey, c.index
+		configured, isConfigured := f.entityKeys[key]
+		if isConfigured {
+			arr, _ := data[key].([]interface{})
+			idKey, index = configured, indexBy(configured, arr)
+		}
+
+		if index == nil {
+			if isConfigured {
+				f.rejectEntityKey(key, strconv.Quote(configured)+" is not a unique id of every object")
+			}
+
+			continue
+		}
+
+		typ := f.collectionType(key)
+		if typ == nil || seen[typ.Name()] {
+			if isConfigured {
+				f.rejectEntityKey(key, "the objects have no type of their own")
+			}
+
+			continue
+		}
+
+		name := f.keyField(typ, idKey)
+		if name == "" {
+			if isConfigured {
+				f.rejectEntityKey(key, strconv.Quote(configured)+" is not a field of "+typ.Name())
+			}
+
+			continue
+		}
+
+		seen[typ.Name()] = true
+		res = append(res, Entity{Type: typ, Key: name, list: key, idKey: idKey, index: index})
+	}
+
+	slices.SortFunc(res, func(a, b Entity) int {
+		return cmp.Compare(a.Type.Name(), b.Type.Name())
+	})
+
+	return res, true
+}
+
+// rejectEntityKey records a configured entity key that identifies no entity.
+func (f *DefaultFieldFactory) rejectEntityKey(list, reason string) {
+	f.report.RejectedEntityKeys = append(f.report.RejectedEntityKeys,
+		RejectedEntityKey{List: list, Key: f.entityKeys[list], Reason: reason})
+}
+
+// keyField returns the name of the field of the JSON key, or "" when the type has no such field.
+func (f *DefaultFieldFactory) keyField(typ *graphql.Object, key string) string {
+	for name, k := range f.fieldKeys[typ.Name()] {
+		if k == key {
+			return name
+		}
+	}
+
+	if _, ok := typ.Fields()[key]; ok {
+		return key
+	}
+
+	return ""
+}
+
+// CreateEntitiesField returns the Federation _entities field resolving representations of the entities
+// to their objects. Representations of unknown objects resolve to null.
+func (f *DefaultFieldFactory) CreateEntitiesField(entities []Entity) *graphql.Field {
+	byName := make(map[string]Entity, len(entities))
+	types := make([]*graphql.Object, 0, len(entities))
+
+	// Objects of different lists may have the same id, so the resolved objects are typed by their offset
+	// in the data of the schema. The field is recreated with the schema, offsets never refer to other data.
+	var resolvedTypes sync.Map // offset -> *graphql.Object
+
+	for _, e := range entities {
+		byName[e.Type.Name()] = e
+		types = append(types, e.Type)
+	}
+
+	union := graphql.NewUnion(graphql.UnionConfig{
+		Name:  "_Entity",
+		Types: types,
+		ResolveType: func(p graphql.ResolveTypeParams) *graphql.Object {
+			offset, ok := f.resolver.Offset(p.Value)
+			if !ok {
+				return nil
+			}
+
+			typ, _ := resolvedTypes.Load(offset)
+			obj, _ := typ.(*graphql.Object)
+
+			return obj
+		},
+	})
+
+	return &graphql.Field{
+		Type: graphql.NewNonNull(graphql.NewList(union)),
+		Args: graphql.FieldConfigArgument{
+			"representations": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(anyScalar))),
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			representations, _ := p.Args["representations"].([]interface{})
+
+			res := make([]interface{}, len(representations))
+			for i, value := range representations {
+				representation, _ := value.(map[string]interface{})
+				typeName, _ := representation["__typename"].(string)
+
+				e, ok := byName[typeName]
+				if !ok {
+					return nil, fmt.Errorf("%w: %q", ErrUnknownEntity, typeName)
+				}
+
+				id, _ := idString(representation[e.Key])
+				pos, ok := e.index[id]
+				if !ok {
+					continue
+				}
+
+				element, err := f.resolveElement(entityParams(p, e), pos)
+				if err != nil {
+					return nil, err
+				}
+
+				if offset, ok := f.resolver.Offset(element); ok {
+					resolvedTypes.Store(offset, e.Type)
+				}
+
+				res[i] = element
+			}
+
+			return res, nil
+		},
+	}
+}
+
+// entityParams returns the parameters resolving the elements of the list of the entity.
+func entityParams(p graphql.ResolveParams, e Entity) graphql.ResolveParams {
+	p.Source, p.Info.FieldName = nil, e.list
+
+	return p
+}
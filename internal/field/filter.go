@@ -0,0 +1,172 @@
+package field
+
+import (
+	"maps"
+
+	"github.com/graphql-go/graphql"
+)
+
+// whereArgs returns the arguments filtering and sorting a list of the given element type.
+func (f *DefaultFieldFactory) whereArgs(elementType graphql.Output) graphql.FieldConfigArgument {
+	obj, ok := unwrapNonNull(elementType).(*graphql.Object)
+	if !ok {
+		return nil
+	}
+
+	args := graphql.FieldConfigArgument{
+		"where": &graphql.ArgumentConfig{
+			Type:        f.whereInput(obj),
+			Description: "Returns only the elements matching every condition.",
+		},
+	}
+
+	if orderBy := f.orderByArg(obj); orderBy != nil {
+		args["orderBy"] = orderBy
+	}
+
+	return args
+}
+
+// whereInput returns the input type filtering objects of the given type, e.g. for
+//
+//	{"name": "item1", "price": 100, "stat": {"level": 5}}
+//
+// it accepts {price: {gt: 50}, stat: {level: {in: [3, 5]}}, OR: [...]}.
+// Fields are defined lazily because AND/OR refer to the input type itself.
+func (f *DefaultFieldFactory) whereInput(obj *graphql.Object) *graphql.InputObject {
+	name := obj.Name() + "Where"
+	if cached, ok := f.inputTypesCache.get(name); ok {
+		return cached
+	}
+
+	var input *graphql.InputObject
+	input = graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: name,
+		Fields: graphql.InputObjectConfigFieldMapThunk(func() graphql.InputObjectConfigFieldMap {
+			fields := graphql.InputObjectConfigFieldMap{
+				"AND": &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(input))},
+				"OR":  &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(input))},
+			}
+
+			for fieldName, def := range obj.Fields() {
+				// Relations aren't part of the JSON object.
+				if f.relationFields[obj.Name()+pathSeparator+fieldName] {
+					continue
+				}
+
+				if filterType := f.filterInput(def.Type); filterType != nil {
+					fields[fieldName] = &graphql.InputObjectFieldConfig{Type: filterType}
+				}
+			}
+
+			return fields
+		}),
+	})
+	f.inputTypesCache.set(name, input)
+
+	return input
+}
+
+// filterInput returns the input type filtering a field of the given type.
+// Lists and JSON values can't be filtered.
+func (f *DefaultFieldFactory) filterInput(t graphql.Output) graphql.Input {
+	switch v := unwrapNonNull(t).(type) {
+	case *graphql.Object:
+		return f.whereInput(v)
+	case *graphql.Enum:
+		return f.leafFilterInput(v)
+	case *graphql.Scalar:
+		if v == jsonScalar {
+			return nil
+		}
+
+		return f.leafFilterInput(v)
+	default:
+		return nil
+	}
+}
+
+// leafFilterInput returns the operators available for a scalar or enum type:
+// eq, neq and in for every type, contains for strings and comparisons for numbers.
+func (f *DefaultFieldFactory) leafFilterInput(scalar graphql.Input) *graphql.InputObject {
+	name := scalar.Name() + "Filter"
+	if cached, ok := f.inputTypesCache.get(name); ok {
+		return cached
+	}
+
+	fields := graphql.InputObjectConfigFieldMap{
+		"eq":  &graphql.InputObjectFieldConfig{Type: scalar},
+		"neq": &graphql.InputObjectFieldConfig{Type: scalar},
+		"in":  &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(scalar))},
+	}
+
+	switch scalar {
+	case graphql.String:
+		fields["contains"] = &graphql.InputObjectFieldConfig{Type: graphql.String}
+	case graphql.Int, graphql.Float:
+		for _, op := range []string{"gt", "gte", "lt", "lte"} {
+			fields[op] = &graphql.InputObjectFieldConfig{Type: scalar}
+		}
+	}
+
+	input := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:   name,
+		Fields: fields,
+	})
+	f.inputTypesCache.set(name, input)
+
+	return input
+}
+
+// resolveWhereKeys translates the field names of the where argument back to the JSON keys
+// they were created from before resolving the list, see fieldNames.
+func (f *DefaultFieldFactory) resolveWhereKeys(elementType graphql.Output, fn graphql.FieldResolveFn) graphql.FieldResolveFn {
+	obj, ok := unwrapNonNull(elementType).(*graphql.Object)
+	if !ok {
+		return fn
+	}
+
+	keys := f.fieldKeys
+
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		if where, ok := p.Args["where"].(map[string]interface{}); ok {
+			p.Args = maps.Clone(p.Args)
+			p.Args["where"] = keys.where(obj, where)
+		}
+
+		return fn(p)
+	}
+}
+
+// where returns the where argument of the object type keyed by JSON keys.
+func (k fieldKeys) where(obj *graphql.Object, where map[string]interface{}) map[string]interface{} {
+	res := make(map[string]interface{}, len(where))
+
+	for name, cond := range where {
+		if name == "AND" || name == "OR" {
+			subs, _ := cond.([]interface{})
+
+			translated := make([]interface{}, 0, len(subs))
+			for _, sub := range subs {
+				if m, ok := sub.(map[string]interface{}); ok {
+					translated = append(translated, k.where(obj, m))
+				}
+			}
+
+			res[name] = translated
+
+			continue
+		}
+
+		if def, ok := obj.Fields()[name]; ok {
+			nested, isObj := unwrapNonNull(def.Type).(*graphql.Object)
+			if m, ok := cond.(map[string]interface{}); ok && isObj {
+				cond = k.where(nested, m)
+			}
+		}
+
+		res[k.key(obj.Name(), name)] = cond
+	}
+
+	return res
+}
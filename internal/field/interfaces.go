@@ -0,0 +1,172 @@
+package field
+
+import (
+	"maps"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+)
+
+// defaultInterfaceMinFields is the least number of shared fields extracted into an interface by default.
+const defaultInterfaceMinFields = 2
+
+// e.g. ["id", "name"] -> "HasIdName"
+func defaultInterfaceNamingFunction(fields []string) string {
+	return "Has" + defaultPathNamingFunction(fields)
+}
+
+// objectInterfaces returns the thunk of the interfaces implemented by the object type.
+// Interfaces are extracted once every object type of the schema exists, see extractInterfaces.
+func (f *DefaultFieldFactory) objectInterfaces(typeName string) graphql.InterfacesThunk {
+	return func() []*graphql.Interface {
+		if f.interfaces == nil {
+			f.interfaces = f.extractInterfaces()
+		}
+
+		return f.interfaces[typeName]
+	}
+}
+
+// extractInterfaces finds the fields shared by object types and returns the interfaces implemented
+// by every type name. A set of at least interfaceMinFields fields with the same names and types
+// found in two types becomes an interface implemented by every type having all of them, e.g.
+//
+//	{"sword": {"id": 1, "name": "Sword", "damage": 5}, "shield": {"id": 2, "name": "Shield", "armor": 3}}
+//
+// results in "interface HasIdName { id: Int name: String }" implemented by both objects.
+// A set is skipped when a larger one is implemented by the same types.
+func (f *DefaultFieldFactory) extractInterfaces() map[string][]*graphql.Interface {
+	types := slices.Clone(f.objectTypes)
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].Name() < types[j].Name()
+	})
+
+	shapes := make([]map[string]string, len(types))
+	for i, t := range types {
+		shapes[i] = make(map[string]string)
+		for name, def := range t.Fields() {
+			shapes[i][name] = def.Type.String()
+		}
+	}
+
+	type candidate struct {
+		fields       []string
+		implementors []int
+	}
+
+	candidates := make(map[string]*candidate)
+	for i := range shapes {
+		for j := i + 1; j < len(shapes); j++ {
+			var fields []string
+			for name, t := range shapes[i] {
+				if shapes[j][name] == t {
+					fields = append(fields, name)
+				}
+			}
+
+			if len(fields) < f.interfaceMinFields {
+				continue
+			}
+
+			sort.Strings(fields)
+
+			signature := shapeSignature(shapes[i], fields)
+			if _, ok := candidates[signature]; ok {
+				continue
+			}
+
+			c := &candidate{fields: fields}
+			for k := range shapes {
+				if hasShape(shapes[k], shapes[i], fields) {
+					c.implementors = append(c.implementors, k)
+				}
+			}
+
+			candidates[signature] = c
+		}
+	}
+
+	signatures := slices.Sorted(maps.Keys(candidates))
+	implemented := make(map[string][]*graphql.Interface)
+	taken := make(map[string]bool)
+
+	for _, signature := range signatures {
+		c := candidates[signature]
+
+		dominated := false
+		for _, other := range candidates {
+			if len(other.fields) > len(c.fields) && slices.Equal(other.implementors, c.implementors) {
+				dominated = true
+
+				break
+			}
+		}
+
+		if dominated {
+			continue
+		}
+
+		owner := types[c.implementors[0]].Fields()
+		fields := graphql.Fields{}
+		for _, name := range c.fields {
+			fields[name] = &graphql.Field{Type: owner[name].Type, Description: owner[name].Description}
+		}
+
+		iface := graphql.NewInterface(graphql.InterfaceConfig{
+			Name:   f.interfaceName(c.fields, taken),
+			Fields: fields,
+		})
+
+		for _, k := range c.implementors {
+			implemented[types[k].Name()] = append(implemented[types[k].Name()], iface)
+		}
+	}
+
+	return implemented
+}
+
+// interfaceName returns a name for the fields that isn't used by another type.
+func (f *DefaultFieldFactory) interfaceName(fields []string, taken map[string]bool) string {
+	base := sanitizeName(f.interfaceNameFn(fields))
+
+	name := base
+	for i := 2; taken[name] || f.typeExists(name); i++ {
+		name = base + strconv.Itoa(i)
+	}
+
+	taken[name] = true
+
+	return name
+}
+
+func (f *DefaultFieldFactory) typeExists(name string) bool {
+	_, object := f.gqlTypesCache.get(name)
+	union := f.unionTypesCache.named(name)
+	_, enum := f.enumTypesCache.get(name)
+
+	return object || union || enum
+}
+
+// shapeSignature identifies the fields of a shape together with their types.
+func shapeSignature(shape map[string]string, fields []string) string {
+	parts := make([]string, len(fields))
+	for i, name := range fields {
+		parts[i] = name + ":" + shape[name]
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// hasShape reports whether the shape has every field of the other shape with the same type.
+func hasShape(shape, other map[string]string, fields []string) bool {
+	for _, name := range fields {
+		if t, ok := shape[name]; !ok || t != other[name] {
+			return false
+		}
+	}
+
+	return true
+}
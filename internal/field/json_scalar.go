@@ -0,0 +1,59 @@
+package field
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// jsonScalar returns any JSON value as is. It types values whose JSON types conflict.
+var jsonScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "JSON",
+	Description: "Any JSON value, used where the data has values of different types.",
+	Serialize:   serializeJSON,
+	ParseValue: func(value interface{}) interface{} {
+		return value
+	},
+	ParseLiteral: parseJSONLiteral,
+})
+
+// serializeJSON unwraps values of resolvers that keep JSON documents, e.g. gjson.Result.
+func serializeJSON(value interface{}) interface{} {
+	if v, ok := value.(interface{ Value() interface{} }); ok {
+		return v.Value()
+	}
+
+	return value
+}
+
+func parseJSONLiteral(valueAST ast.Value) interface{} {
+	switch v := valueAST.(type) {
+	case *ast.ObjectValue:
+		obj := make(map[string]interface{}, len(v.Fields))
+		for _, field := range v.Fields {
+			obj[field.Name.Value] = parseJSONLiteral(field.Value)
+		}
+
+		return obj
+	case *ast.ListValue:
+		list := make([]interface{}, 0, len(v.Values))
+		for _, item := range v.Values {
+			list = append(list, parseJSONLiteral(item))
+		}
+
+		return list
+	case *ast.IntValue:
+		return graphql.Int.ParseLiteral(v)
+	case *ast.FloatValue:
+		return graphql.Float.ParseLiteral(v)
+	default:
+		return valueAST.GetValue()
+	}
+}
+
+// createJSONField returns a field resolving the value as is.
+func (f *DefaultFieldFactory) createJSONField() *graphql.Field {
+	return &graphql.Field{
+		Type:    jsonScalar,
+		Resolve: f.resolver.ResolveScalarValue,
+	}
+}
@@ -0,0 +1,180 @@
+package field
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+)
+
+// idKey is the key typed as ID.
+const idKey = "id"
+
+// idIndex returns the key identifying the objects of a list together with the positions of the objects by id,
+// or "" when no key is a unique id of every object. The id is "id" or the singular of the list key followed
+// by "Id", e.g. "userId" for "users". Ids are strings or integers.
+func idIndex(key string, arr []interface{}) (string, map[string]int) {
+	candidates := []string{idKey}
+	if one := singular(key); one != key {
+		candidates = append(candidates, one+"Id")
+	}
+
+	for _, candidate := range candidates {
+		if index := indexBy(candidate, arr); index != nil {
+			return candidate, index
+		}
+	}
+
+	return "", nil
+}
+
+// indexBy returns the positions of the objects by the value of the key, or nil when it isn't a unique id.
+func indexBy(key string, arr []interface{}) map[string]int {
+	index := make(map[string]int, len(arr))
+	for i, item := range arr {
+		obj, ok := item.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+
+		id, ok := idString(obj[key])
+		if !ok {
+			return nil
+		}
+
+		if _, duplicate := index[id]; duplicate {
+			return nil
+		}
+
+		index[id] = i
+	}
+
+	return index
+}
+
+// idString returns the text of an id the way ID arguments are written, e.g. "7" for 7.
+func idString(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case int:
+		return strconv.Itoa(v), true
+	case json.Number:
+		if _, err := strconv.ParseInt(v.String(), 10, 64); err == nil {
+			return v.String(), true
+		}
+	case float64:
+		if v == float64(int64(v)) {
+			return strconv.FormatInt(int64(v), 10), true
+		}
+	}
+
+	return "", false
+}
+
+// singular returns the singular of an English plural, e.g. "users" -> "user", "categories" -> "category",
+// or the word itself when it doesn't look like a plural.
+func singular(word string) string {
+	switch {
+	case strings.HasSuffix(word, "ies") && len(word) > 3:
+		return strings.TrimSuffix(word, "ies") + "y"
+	case strings.HasSuffix(word, "sses"), strings.HasSuffix(word, "shes"), strings.HasSuffix(word, "ches"),
+		strings.HasSuffix(word, "xes"), strings.HasSuffix(word, "zes"):
+		return strings.TrimSuffix(word, "es")
+	case strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") && len(word) > 1:
+		return strings.TrimSuffix(word, "s")
+	default:
+		return word
+	}
+}
+
+// lookupName names the field looking up an element of the list field by id, e.g. "user" for "users".
+// Lists whose name isn't a plural get a "ById" suffix, e.g. "inventoryById".
+func lookupName(listName string) string {
+	if one := singular(listName); one != listName {
+		return one
+	}
+
+	return listName + "ById"
+}
+
+// addLookups indexes the objects of a root list by their id. The list gets an "ids" argument selecting
+// objects by id and the returned field looks up a single object, e.g. user(id: ID!). Returns nil when
+// the objects have no unique id.
+func (f *DefaultFieldFactory) addLookups(list *graphql.Field, key string, value interface{}) *graphql.Field {
+	arr, ok := value.([]interface{})
+	if !ok || len(arr) == 0 {
+		return nil
+	}
+
+	listType, ok := unwrapNonNull(list.Type).(*graphql.List)
+	if !ok || list.Resolve == nil {
+		return nil
+	}
+
+	elementType := unwrapNonNull(listType.OfType)
+	if _, isObj := elementType.(*graphql.Object); !isObj {
+		if _, isUnion := elementType.(*graphql.Union); !isUnion {
+			return nil
+		}
+	}
+
+	_, index := idIndex(key, arr)
+	if index == nil {
+		return nil
+	}
+
+	byIDs := f.resolveWhereKeys(elementType, func(p graphql.ResolveParams) (interface{}, error) {
+		ids, _ := p.Args["ids"].([]interface{})
+
+		positions := make([]int, 0, len(ids))
+		for _, id := range ids {
+			if pos, ok := index[id.(string)]; ok {
+				positions = append(positions, pos)
+			}
+		}
+
+		return f.resolver.ResolveElements(p, positions)
+	})
+
+	resolveAll := list.Resolve
+	list.Args["ids"] = &graphql.ArgumentConfig{
+		Type:        graphql.NewList(graphql.NewNonNull(graphql.ID)),
+		Description: "Returns only the elements with the given ids, in the given order.",
+	}
+	list.Resolve = func(p graphql.ResolveParams) (interface{}, error) {
+		if _, ok := p.Args["ids"].([]interface{}); ok {
+			return byIDs(p)
+		}
+
+		return resolveAll(p)
+	}
+
+	return &graphql.Field{
+		Type: elementType,
+		Args: graphql.FieldConfigArgument{
+			"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			pos, ok := index[p.Args["id"].(string)]
+			if !ok {
+				return nil, nil
+			}
+
+			return f.resolveElement(p, pos)
+		},
+	}
+}
+
+// resolveElement resolves the element at the position of the array of the field.
+func (f *DefaultFieldFactory) resolveElement(p graphql.ResolveParams, pos int) (interface{}, error) {
+	p.Args = nil
+
+	elements, err := f.resolver.ResolveElements(p, []int{pos})
+	if list, _ := elements.([]interface{}); len(list) > 0 {
+		return list[0], err
+	}
+
+	return nil, err
+}
@@ -0,0 +1,28 @@
+package field
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSingular verifies the singular of common English plurals.
+func TestSingular(t *testing.T) {
+	tests := []struct {
+		word     string
+		expected string
+	}{
+		{word: "users", expected: "user"},
+		{word: "categories", expected: "category"},
+		{word: "boxes", expected: "box"},
+		{word: "matches", expected: "match"},
+		{word: "addresses", expected: "address"},
+		{word: "class", expected: "class"},
+		{word: "data", expected: "data"},
+		{word: "s", expected: "s"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, singular(test.word), test.word)
+	}
+}
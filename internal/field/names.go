@@ -0,0 +1,115 @@
+package field
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FieldNames returns the GraphQL field names for the JSON keys of one object of the given type
+// and records the renamed keys in the report.
+func (f *DefaultFieldFactory) FieldNames(typeName string, keys []string) map[string]string {
+	names := fieldNames(keys)
+	f.reportRenames(typeName, names)
+
+	return names
+}
+
+// fieldNames maps the JSON keys of one object to GraphQL field names.
+// Valid keys keep their names, the others are sanitized, e.g. "first-name" -> "firstName",
+// "2fa_enabled" -> "_2faEnabled", "@type" -> "type". A sanitized name that is already
+// taken gets a numeric suffix, e.g. "type2".
+func fieldNames(keys []string) map[string]string {
+	names := make(map[string]string, len(keys))
+	taken := make(map[string]bool, len(keys))
+
+	var invalid []string
+	for _, key := range keys {
+		if isValidName(key) {
+			names[key] = key
+			taken[key] = true
+		} else {
+			invalid = append(invalid, key)
+		}
+	}
+
+	sort.Strings(invalid)
+
+	for _, key := range invalid {
+		base := sanitizeName(key)
+
+		name := base
+		for i := 2; taken[name]; i++ {
+			name = base + strconv.Itoa(i)
+		}
+
+		names[key] = name
+		taken[name] = true
+	}
+
+	return names
+}
+
+// isValidName reports whether the name matches /[_A-Za-z][_0-9A-Za-z]*/ and
+// does not start with "__", which is reserved for introspection.
+func isValidName(name string) bool {
+	if name == "" || strings.HasPrefix(name, "__") {
+		return false
+	}
+
+	for i, r := range name {
+		switch {
+		case r == '_', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case isDigit(r) && i > 0:
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// sanitizeName turns any string into a valid GraphQL name by camel casing the words
+// separated by characters that aren't allowed in names.
+func sanitizeName(s string) string {
+	if isValidName(s) {
+		return s
+	}
+
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !isNameLetter(r) && !isDigit(r)
+	})
+
+	var b strings.Builder
+	for i, word := range words {
+		first := word[:1]
+		if i == 0 {
+			first = strings.ToLower(first)
+		} else {
+			first = strings.ToUpper(first)
+		}
+
+		b.WriteString(first)
+		b.WriteString(word[1:])
+	}
+
+	name := b.String()
+	if name == "" {
+		return "field"
+	}
+
+	if isDigit(rune(name[0])) {
+		name = "_" + name
+	}
+
+	return name
+}
+
+// isNameLetter reports whether the rune is an ASCII letter, the only letters allowed in names.
+func isNameLetter(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
@@ -0,0 +1,27 @@
+package field
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFieldNames verifies that invalid keys are sanitized and deduplicated while valid keys are kept.
+func TestFieldNames(t *testing.T) {
+	names := fieldNames([]string{"name", "first-name", "firstName", "first_name", "2fa", "@type", "$ref", "__typename", "", "!!", "type"})
+
+	expected := map[string]string{
+		"name":       "name",
+		"first-name": "firstName2",
+		"firstName":  "firstName",
+		"first_name": "first_name",
+		"2fa":        "_2fa",
+		"@type":      "type2",
+		"$ref":       "ref",
+		"__typename": "typename",
+		"":           "field",
+		"!!":         "field2",
+		"type":       "type",
+	}
+	assert.Equal(t, expected, names)
+}
@@ -1,6 +1,129 @@
 package field
 
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// NamingStrategy selects how objects are grouped into GraphQL types and how the types are named.
+type NamingStrategy int
+
+const (
+	// NamingByKey groups objects by their bare key, e.g. "user.address" and "company.address"
+	// both become "addressObject". Names are created by Config.GQLObjectNamingFn.
+	NamingByKey NamingStrategy = iota
+	// NamingByPath groups objects by their path from the root, e.g. "user.address" becomes
+	// "UserAddress" and "company.address" becomes "CompanyAddress". Names are created by
+	// Config.GQLPathNamingFn. Paths whose names collide share a type only when their shapes are compatible.
+	NamingByPath
+)
+
+// pathSeparator joins the keys of a path into a type key.
+const pathSeparator = "."
+
+// keyEscaper escapes the characters separating the parts of type keys and slots, so keys like
+// "version.major", "#tags" or "tags[]" don't collide with paths, variants or list elements.
+var keyEscaper = strings.NewReplacer(`\`, `\\`, pathSeparator, `\`+pathSeparator, variantSeparator, `\`+variantSeparator, "[", `\[`)
+
+// escapeKey returns the key as a part of a type key or slot, see splitTypeKey.
+func escapeKey(key string) string {
+	return keyEscaper.Replace(key)
+}
+
 // e.g. "user" -> "userObject"
 func defaultObjectNamingFunciton(key string) string {
 	return key + "Object"
 }
+
+// e.g. ["user", "home_address"] -> "UserHomeAddress"
+func defaultPathNamingFunction(path []string) string {
+	var b strings.Builder
+	for _, key := range path {
+		b.WriteString(pascalCase(key))
+	}
+
+	return b.String()
+}
+
+// pascalCase upper cases the first letter of every word, words are separated by
+// anything but letters and digits, e.g. "home_address" -> "HomeAddress".
+func pascalCase(s string) string {
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var b strings.Builder
+	for _, word := range words {
+		runes := []rune(word)
+		b.WriteRune(unicode.ToUpper(runes[0]))
+		b.WriteString(string(runes[1:]))
+	}
+
+	return b.String()
+}
+
+// childKey returns the type key of the object stored under the key of the parent object.
+func (f *DefaultFieldFactory) childKey(parent, key string) string {
+	if f.naming != NamingByPath || parent == rootKey {
+		return escapeKey(key)
+	}
+
+	return parent + pathSeparator + escapeKey(key)
+}
+
+// typeName returns the GraphQL name of the object type for the type key.
+// Names built from keys that aren't valid GraphQL names are sanitized. With NamingByKey a name taken
+// by another key, e.g. "user-name" and "userName" both sanitized to "userNameObject", gets a numeric
+// suffix and is reported, see Report.RenamedTypes. With NamingByPath collisions are resolved by shape,
+// see compatibleType.
+func (f *DefaultFieldFactory) typeName(typeKey string) string {
+	if f.naming == NamingByPath {
+		return sanitizeName(f.pathNameFn(splitTypeKey(typeKey)))
+	}
+
+	base := sanitizeName(f.objectNameFn(strings.Join(splitTypeKey(typeKey), pathSeparator)))
+
+	name := base
+	for i := 2; ; i++ {
+		owner, taken := f.typeKeys[name]
+		if !taken {
+			f.typeKeys[name] = typeKey
+			if name != base {
+				f.report.RenamedTypes = append(f.report.RenamedTypes, TypeRename{Key: typeKey, Type: name})
+			}
+
+			return name
+		}
+
+		if owner == typeKey {
+			return name
+		}
+
+		name = base + strconv.Itoa(i)
+	}
+}
+
+// splitTypeKey returns the unescaped keys of the path of a type key.
+func splitTypeKey(typeKey string) []string {
+	var keys []string
+	var key strings.Builder
+
+	escaped := false
+	for _, r := range typeKey {
+		switch {
+		case escaped:
+			key.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case string(r) == pathSeparator:
+			keys = append(keys, key.String())
+			key.Reset()
+		default:
+			key.WriteRune(r)
+		}
+	}
+
+	return append(keys, key.String())
+}
@@ -0,0 +1,42 @@
+package field
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDefaultPathNaming verifies that paths are joined into PascalCase type names.
+func TestDefaultPathNaming(t *testing.T) {
+	tests := []struct {
+		path     []string
+		expected string
+	}{
+		{path: []string{"user"}, expected: "User"},
+		{path: []string{"user", "address"}, expected: "UserAddress"},
+		{path: []string{"users", "home_address"}, expected: "UsersHomeAddress"},
+		{path: []string{"item", "tier-stats", "maxLevel"}, expected: "ItemTierStatsMaxLevel"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, defaultPathNamingFunction(test.path))
+	}
+}
+
+// TestSplitTypeKey verifies that escaped keys are split and unescaped.
+func TestSplitTypeKey(t *testing.T) {
+	tests := []struct {
+		typeKey  string
+		expected []string
+	}{
+		{typeKey: "user", expected: []string{"user"}},
+		{typeKey: "user.address", expected: []string{"user", "address"}},
+		{typeKey: escapeKey("version.major"), expected: []string{"version.major"}},
+		{typeKey: "feed." + variantSeparator + escapeKey("a.b"), expected: []string{"feed", "#a.b"}},
+		{typeKey: escapeKey(`a\b`) + "." + escapeKey("#tags[]"), expected: []string{`a\b`, "#tags[]"}},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, splitTypeKey(test.typeKey))
+	}
+}
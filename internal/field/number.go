@@ -0,0 +1,20 @@
+package field
+
+import (
+	"encoding/json"
+	"math"
+)
+
+// numberIsInt reports whether the JSON number is integral and fits into the 32 bits of a GraphQL Int.
+// A json.Number has to be written as an integer, e.g. 1.0 and 1e3 are Floats.
+func numberIsInt(value interface{}) bool {
+	switch v := value.(type) {
+	case json.Number:
+		i, err := v.Int64()
+		return err == nil && i >= math.MinInt32 && i <= math.MaxInt32
+	case float64:
+		return v == math.Trunc(v) && v >= math.MinInt32 && v <= math.MaxInt32
+	default:
+		return false
+	}
+}
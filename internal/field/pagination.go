@@ -0,0 +1,187 @@
+package field
+
+import (
+	"sort"
+
+	"github.com/graphql-go/graphql"
+)
+
+// sortDirectionEnum is shared by every orderBy input.
+var sortDirectionEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name: "SortDirection",
+	Values: graphql.EnumValueConfigMap{
+		"ASC":  &graphql.EnumValueConfig{Value: "ASC"},
+		"DESC": &graphql.EnumValueConfig{Value: "DESC"},
+	},
+})
+
+// pageInfoType is shared by every connection.
+var pageInfoType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "PageInfo",
+	Fields: graphql.Fields{
+		"hasNextPage":     &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+		"hasPreviousPage": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+		"startCursor":     &graphql.Field{Type: graphql.String},
+		"endCursor":       &graphql.Field{Type: graphql.String},
+	},
+})
+
+// listArgs returns the arguments of a list field: limit and offset for every list,
+// plus where and orderBy for lists of objects.
+func (f *DefaultFieldFactory) listArgs(elementType graphql.Output) graphql.FieldConfigArgument {
+	args := f.whereArgs(elementType)
+	if args == nil {
+		args = graphql.FieldConfigArgument{}
+	}
+
+	args["limit"] = &graphql.ArgumentConfig{
+		Type:        graphql.Int,
+		Description: "Maximum number of elements to return.",
+	}
+	args["offset"] = &graphql.ArgumentConfig{
+		Type:        graphql.Int,
+		Description: "Number of elements to skip.",
+	}
+
+	return args
+}
+
+// orderByArg returns the orderBy argument sorting objects of the given type by their scalar fields.
+func (f *DefaultFieldFactory) orderByArg(obj *graphql.Object) *graphql.ArgumentConfig {
+	orderFields := f.orderFieldEnum(obj)
+	if orderFields == nil {
+		return nil
+	}
+
+	name := obj.Name() + "OrderBy"
+	input, ok := f.inputTypesCache.get(name)
+	if !ok {
+		input = graphql.NewInputObject(graphql.InputObjectConfig{
+			Name: name,
+			Fields: graphql.InputObjectConfigFieldMap{
+				"field":     &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(orderFields)},
+				"direction": &graphql.InputObjectFieldConfig{Type: sortDirectionEnum, DefaultValue: "ASC"},
+			},
+		})
+		f.inputTypesCache.set(name, input)
+	}
+
+	return &graphql.ArgumentConfig{
+		Type:        graphql.NewList(graphql.NewNonNull(input)),
+		Description: "Sorts the elements, later entries break ties of earlier ones.",
+	}
+}
+
+// orderFieldEnum returns an enum of the scalar fields of the object type,
+// or nil if the type has no scalar fields to sort by.
+func (f *DefaultFieldFactory) orderFieldEnum(obj *graphql.Object) *graphql.Enum {
+	name := obj.Name() + "OrderField"
+	if cached, ok := f.enumTypesCache.get(name); ok {
+		return cached
+	}
+
+	var sortable []string
+	for fieldName, def := range obj.Fields() {
+		switch t := unwrapNonNull(def.Type); t.(type) {
+		case *graphql.Scalar, *graphql.Enum:
+			if t != jsonScalar {
+				sortable = append(sortable, fieldName)
+			}
+		}
+	}
+
+	if len(sortable) == 0 {
+		return nil
+	}
+
+	sort.Strings(sortable)
+
+	// Values are the JSON keys the resolver sorts by.
+	values := graphql.EnumValueConfigMap{}
+	for _, fieldName := range sortable {
+		values[fieldName] = &graphql.EnumValueConfig{Value: f.fieldKeys.key(obj.Name(), fieldName)}
+	}
+
+	enum := graphql.NewEnum(graphql.EnumConfig{
+		Name:   name,
+		Values: values,
+	})
+	f.enumTypesCache.set(name, enum)
+
+	return enum
+}
+
+// connectionField returns a Relay style connection over the same JSON array as the list field.
+// It is exposed next to the list as "<key>Connection".
+func (f *DefaultFieldFactory) connectionField(key string, list *graphql.Field) *graphql.Field {
+	listType, ok := unwrapNonNull(list.Type).(*graphql.List)
+	if !ok {
+		return nil
+	}
+
+	node, ok := unwrapNonNull(listType.OfType).(*graphql.Object)
+	if !ok {
+		return nil
+	}
+
+	args := graphql.FieldConfigArgument{
+		"first":  &graphql.ArgumentConfig{Type: graphql.Int},
+		"after":  &graphql.ArgumentConfig{Type: graphql.String},
+		"last":   &graphql.ArgumentConfig{Type: graphql.Int},
+		"before": &graphql.ArgumentConfig{Type: graphql.String},
+	}
+
+	for name, arg := range f.whereArgs(node) {
+		args[name] = arg
+	}
+
+	return &graphql.Field{
+		Type:    f.connectionType(node),
+		Args:    args,
+		Resolve: resolveKey(key, f.resolveWhereKeys(node, f.resolver.ResolveConnection)),
+	}
+}
+
+func (f *DefaultFieldFactory) connectionType(node *graphql.Object) *graphql.Object {
+	name := node.Name() + "Connection"
+	if cached, ok := f.gqlTypesCache.get(name); ok {
+		return cached
+	}
+
+	edge := graphql.NewObject(graphql.ObjectConfig{
+		Name: node.Name() + "Edge",
+		Fields: graphql.Fields{
+			"node":   &graphql.Field{Type: graphql.NewNonNull(node)},
+			"cursor": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+
+	connection := graphql.NewObject(graphql.ObjectConfig{
+		Name: name,
+		Fields: graphql.Fields{
+			"edges":      &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(edge)))},
+			"pageInfo":   &graphql.Field{Type: graphql.NewNonNull(pageInfoType)},
+			"totalCount": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		},
+	})
+	f.gqlTypesCache.set(name, connection)
+
+	return connection
+}
+
+// resolveKey resolves a field from a JSON key other than the field name.
+func resolveKey(key string, fn graphql.FieldResolveFn) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		p.Info.FieldName = key
+
+		return fn(p)
+	}
+}
+
+func unwrapNonNull(t graphql.Output) graphql.Output {
+	if nonNull, ok := t.(*graphql.NonNull); ok {
+		return nonNull.OfType
+	}
+
+	return t
+}
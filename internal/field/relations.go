@@ -0,0 +1,356 @@
+package field
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+)
+
+// Relation links the objects of the top-level list From to the objects of the top-level list To
+// whose id is the value of Key, e.g. orders[].userId -> users[].id. Key holds an id or a list of ids.
+type Relation struct {
+	From string `json:"from"` // key of the referencing list, e.g. "orders"
+	Key  string `json:"key"`  // key of the referencing objects, e.g. "userId"
+	To   string `json:"to"`   // key of the referenced list, e.g. "users", empty to drop an inferred relation
+	// Field navigates to the referenced objects, by default Key without "Id", e.g. "user",
+	// or the plural of Key without "Ids", e.g. "tags" for "tagIds".
+	Field string `json:"field,omitempty"`
+	// Reverse lists the referencing objects on the referenced ones, by default the name of From,
+	// e.g. "orders", "-" for none.
+	Reverse string `json:"reverse,omitempty"`
+}
+
+// RejectedRelation is a configured relation that was not added, e.g. because its lists don't exist.
+type RejectedRelation struct {
+	Relation
+	Reason string
+}
+
+// noReverse disables the reverse field of a relation.
+const noReverse = "-"
+
+// collection is a top-level list of objects.
+type collection struct {
+	key     string
+	objects []map[string]interface{}
+	index   map[string]int // id -> position, nil when the objects have no unique id
+	idKey   string
+}
+
+// collectionsOf returns the top-level lists of objects of the data.
+func collectionsOf(data map[string]interface{}) map[string]*collection {
+	res := make(map[string]*collection)
+	for key, value := range data {
+		arr, ok := value.([]interface{})
+		if !ok || len(arr) == 0 {
+			continue
+		}
+
+		isObjects, objects := allMaps(arr)
+		if !isObjects {
+			continue
+		}
+
+		idKey, index := idIndex(key, arr)
+		res[key] = &collection{key: key, objects: objects, index: index, idKey: idKey}
+	}
+
+	return res
+}
+
+// AddRelations adds the fields navigating between related top-level lists of the data,
+// see Relation. Relations of Config.Relations replace the inferred relations of the same key.
+func (f *DefaultFieldFactory) AddRelations(data map[string]interface{}) {
+	if !f.inferRelations && len(f.relations) == 0 {
+		return
+	}
+
+	collections := collectionsOf(data)
+	rootNames := fieldNames(slices.Sorted(maps.Keys(data)))
+
+	configured, inferred := f.relationsOf(collections)
+
+	for _, r := range configured {
+		if r, reason := f.addRelation(r, collections, rootNames); reason != "" {
+			f.report.RejectedRelations = append(f.report.RejectedRelations, RejectedRelation{Relation: r, Reason: reason})
+		} else {
+			f.report.Relations = append(f.report.Relations, r)
+		}
+	}
+
+	// Inferred relations are dropped silently, e.g. when the data has a field of the same name.
+	for _, r := range inferred {
+		if r, reason := f.addRelation(r, collections, rootNames); reason == "" {
+			f.report.Relations = append(f.report.Relations, r)
+		}
+	}
+}
+
+// relationsOf returns the configured relations and the inferred ones not replaced by them, when enabled.
+func (f *DefaultFieldFactory) relationsOf(collections map[string]*collection) (configured, inferred []Relation) {
+	keys := make(map[[2]string]bool, len(f.relations))
+
+	for _, r := range f.relations {
+		keys[[2]string{r.From, r.Key}] = true
+
+		if r.To != "" {
+			configured = append(configured, r)
+		}
+	}
+
+	if !f.inferRelations {
+		return configured, nil
+	}
+
+	for _, r := range inferRelations(collections) {
+		if !keys[[2]string{r.From, r.Key}] {
+			inferred = append(inferred, r)
+		}
+	}
+
+	return configured, inferred
+}
+
+// inferRelations finds the keys named "<entity>Id" or "<entity>Ids" whose values are all ids of another
+// list named after the entity, e.g. "userId" referencing "users".
+func inferRelations(collections map[string]*collection) []Relation {
+	var res []Relation
+	for _, fromKey := range slices.Sorted(maps.Keys(collections)) {
+		from := collections[fromKey]
+
+		for _, key := range objectKeys(from.objects) {
+			entity, isID := strings.CutSuffix(key, "Id")
+			if !isID {
+				entity, isID = strings.CutSuffix(key, "Ids")
+			}
+
+			if !isID || entity == "" || key == from.idKey {
+				continue
+			}
+
+			for _, toKey := range slices.Sorted(maps.Keys(collections)) {
+				to := collections[toKey]
+				if to == from || to.index == nil || (singular(toKey) != entity && toKey != entity) {
+					continue
+				}
+
+				if _, matched, ok := references(from, key, to); ok && matched {
+					res = append(res, Relation{From: fromKey, Key: key, To: toKey})
+
+					break
+				}
+			}
+		}
+	}
+
+	return res
+}
+
+// objectKeys returns the keys of all objects, sorted.
+func objectKeys(objects []map[string]interface{}) []string {
+	keys := make(map[string]bool)
+	for _, obj := range objects {
+		for key := range obj {
+			keys[key] = true
+		}
+	}
+
+	return slices.Sorted(maps.Keys(keys))
+}
+
+// references reports whether the key holds single ids or lists of ids, whether every id is one of the
+// referenced list and whether the values of the key are ids at all. Null values are ignored.
+func references(from *collection, key string, to *collection) (many, matched, ok bool) {
+	var single, found bool
+	matched = true
+
+	match := func(value interface{}) bool {
+		id, isID := idString(value)
+		if isID {
+			_, exists := to.index[id]
+			matched = matched && exists
+			found = true
+		}
+
+		return isID
+	}
+
+	for _, obj := range from.objects {
+		switch v := obj[key].(type) {
+		case nil:
+		case []interface{}:
+			many = true
+			for _, item := range v {
+				if !match(item) {
+					return false, false, false
+				}
+			}
+		default:
+			single = true
+			if !match(v) {
+				return false, false, false
+			}
+		}
+	}
+
+	return many, matched && found, found && single != many
+}
+
+// addRelation adds the fields of the relation to the object types of both lists and returns the relation
+// with its defaults, or the reason it was not added. Fields that exist in the data already are not replaced.
+func (f *DefaultFieldFactory) addRelation(r Relation, collections map[string]*collection, rootNames map[string]string) (Relation, string) {
+	from, to := collections[r.From], collections[r.To]
+	if from == nil {
+		return r, strconv.Quote(r.From) + " is not a top-level list of objects"
+	}
+
+	if to == nil {
+		return r, strconv.Quote(r.To) + " is not a top-level list of objects"
+	}
+
+	if to.index == nil {
+		return r, strconv.Quote(r.To) + " has no unique ids"
+	}
+
+	fromType, toType := f.collectionType(r.From), f.collectionType(r.To)
+	if fromType == nil || toType == nil {
+		return r, "the lists have no object types"
+	}
+
+	many, _, ok := references(from, r.Key, to)
+	if !ok {
+		return r, strconv.Quote(r.Key) + " holds no ids"
+	}
+
+	if r.Field == "" {
+		if many {
+			r.Field = sanitizeName(plural(strings.TrimSuffix(r.Key, "Ids")))
+		} else {
+			r.Field = sanitizeName(strings.TrimSuffix(r.Key, "Id"))
+		}
+	}
+
+	if r.Reverse == "" {
+		r.Reverse = rootNames[r.From]
+	}
+
+	if !isValidName(r.Field) {
+		return r, strconv.Quote(r.Field) + " is not a valid field name"
+	}
+
+	if _, exists := fromType.Fields()[r.Field]; exists {
+		return r, "field " + strconv.Quote(r.Field) + " exists already"
+	}
+
+	fromType.AddFieldConfig(r.Field, f.referenceField(r.Key, to, toType, many))
+	f.relationFields[fromType.Name()+pathSeparator+r.Field] = true
+
+	if _, exists := toType.Fields()[r.Reverse]; !exists && isValidName(r.Reverse) {
+		toType.AddFieldConfig(r.Reverse, f.reverseField(r.Key, from, to, fromType))
+		f.relationFields[toType.Name()+pathSeparator+r.Reverse] = true
+	} else {
+		r.Reverse = noReverse
+	}
+
+	return r, ""
+}
+
+// collectionType returns the object type of the objects of a top-level list.
+func (f *DefaultFieldFactory) collectionType(key string) *graphql.Object {
+	typeName, ok := f.typeNames[f.childKey(rootKey, key)]
+	if !ok {
+		return nil
+	}
+
+	obj, _ := f.gqlTypesCache.get(typeName)
+
+	return obj
+}
+
+// referenceField resolves the objects of the list whose ids are the value of the key.
+func (f *DefaultFieldFactory) referenceField(key string, to *collection, toType *graphql.Object, many bool) *graphql.Field {
+	if !many {
+		return &graphql.Field{
+			Type: toType,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				id, _ := idString(f.resolver.Lookup(p.Source, key))
+				pos, ok := to.index[id]
+				if !ok {
+					return nil, nil
+				}
+
+				p.Source, p.Info.FieldName = nil, to.key
+
+				return f.resolveElement(p, pos)
+			},
+		}
+	}
+
+	return &graphql.Field{
+		Type: graphql.NewList(toType),
+		Args: f.listArgs(toType),
+		Resolve: f.resolveWhereKeys(toType, func(p graphql.ResolveParams) (interface{}, error) {
+			ids, _ := f.resolver.Lookup(p.Source, key).([]interface{})
+
+			positions := make([]int, 0, len(ids))
+			for _, value := range ids {
+				id, _ := idString(value)
+				if pos, ok := to.index[id]; ok {
+					positions = append(positions, pos)
+				}
+			}
+
+			p.Source, p.Info.FieldName = nil, to.key
+
+			return f.resolver.ResolveElements(p, positions)
+		}),
+	}
+}
+
+// reverseField resolves the objects of the referencing list whose key holds the id of the object.
+func (f *DefaultFieldFactory) reverseField(key string, from, to *collection, fromType *graphql.Object) *graphql.Field {
+	referencing := make(map[string][]int)
+	for i, obj := range from.objects {
+		ids := []interface{}{obj[key]}
+		if list, ok := obj[key].([]interface{}); ok {
+			ids = list
+		}
+
+		seen := make(map[string]bool)
+		for _, value := range ids {
+			if id, ok := idString(value); ok && !seen[id] {
+				seen[id] = true
+				referencing[id] = append(referencing[id], i)
+			}
+		}
+	}
+
+	return &graphql.Field{
+		Type: graphql.NewList(fromType),
+		Args: f.listArgs(fromType),
+		Resolve: f.resolveWhereKeys(fromType, func(p graphql.ResolveParams) (interface{}, error) {
+			id, _ := idString(f.resolver.Lookup(p.Source, to.idKey))
+			p.Source, p.Info.FieldName = nil, from.key
+
+			return f.resolver.ResolveElements(p, referencing[id])
+		}),
+	}
+}
+
+// plural returns the plural of an English noun, e.g. "tag" -> "tags", "category" -> "categories".
+func plural(word string) string {
+	switch {
+	case word == "":
+		return word
+	case strings.HasSuffix(word, "y") && len(word) > 1 && !strings.ContainsRune("aeiou", rune(word[len(word)-2])):
+		return strings.TrimSuffix(word, "y") + "ies"
+	case strings.HasSuffix(word, "s"), strings.HasSuffix(word, "x"), strings.HasSuffix(word, "z"),
+		strings.HasSuffix(word, "ch"), strings.HasSuffix(word, "sh"):
+		return word + "es"
+	default:
+		return word + "s"
+	}
+}
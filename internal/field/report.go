@@ -0,0 +1,89 @@
+package field
+
+import (
+	"cmp"
+	"slices"
+	"sort"
+)
+
+// Report describes how the JSON data was adjusted to fit into a GraphQL schema.
+type Report struct {
+	// Renamed lists the JSON keys that are not valid GraphQL names.
+	Renamed []Rename
+	// RenamedTypes lists the object types whose name was taken by the type of another key.
+	RenamedTypes []TypeRename
+	// Conflicts lists the keys with values of different types, they are typed as JSON.
+	Conflicts []Conflict
+	// Relations lists the relations between top-level lists added as fields.
+	Relations []Relation
+	// RejectedRelations lists the configured relations that were not added, with the reason.
+	RejectedRelations []RejectedRelation
+	// RejectedEntityKeys lists the configured entity keys that identify no entity, with the reason.
+	RejectedEntityKeys []RejectedEntityKey
+}
+
+// Rename maps a JSON key of an object to the name of its GraphQL field.
+type Rename struct {
+	Type  string // name of the GraphQL type the field belongs to
+	Key   string // JSON key
+	Field string // GraphQL field name
+}
+
+// TypeRename maps the type key of objects to the name of their GraphQL type, when the name derived
+// from the key was taken, e.g. "user-name" -> "userNameObject2" next to "userName" -> "userNameObject".
+type TypeRename struct {
+	Key  string // type key, the JSON key with NamingByKey
+	Type string // GraphQL type name
+}
+
+// Report returns the report of the last built schema.
+func (f *DefaultFieldFactory) Report() Report {
+	renamed := make([]Rename, len(f.report.Renamed))
+	copy(renamed, f.report.Renamed)
+
+	sort.Slice(renamed, func(i, j int) bool {
+		if renamed[i].Type != renamed[j].Type {
+			return renamed[i].Type < renamed[j].Type
+		}
+
+		return renamed[i].Key < renamed[j].Key
+	})
+
+	relations := slices.Clone(f.report.Relations)
+	slices.SortFunc(relations, func(a, b Relation) int {
+		return cmp.Or(cmp.Compare(a.From, b.From), cmp.Compare(a.Key, b.Key))
+	})
+
+	rejected := slices.Clone(f.report.RejectedRelations)
+	slices.SortFunc(rejected, func(a, b RejectedRelation) int {
+		return cmp.Or(cmp.Compare(a.From, b.From), cmp.Compare(a.Key, b.Key))
+	})
+
+	rejectedKeys := slices.Clone(f.report.RejectedEntityKeys)
+	slices.SortFunc(rejectedKeys, func(a, b RejectedEntityKey) int {
+		return cmp.Compare(a.List, b.List)
+	})
+
+	renamedTypes := slices.Clone(f.report.RenamedTypes)
+	slices.SortFunc(renamedTypes, func(a, b TypeRename) int {
+		return cmp.Compare(a.Key, b.Key)
+	})
+
+	return Report{
+		Renamed:            renamed,
+		RenamedTypes:       renamedTypes,
+		Conflicts:          f.conflicts(),
+		Relations:          relations,
+		RejectedRelations:  rejected,
+		RejectedEntityKeys: rejectedKeys,
+	}
+}
+
+// reportRenames records the keys of the type that got a different field name.
+func (f *DefaultFieldFactory) reportRenames(typeName string, names map[string]string) {
+	for key, name := range names {
+		if key != name {
+			f.report.Renamed = append(f.report.Renamed, Rename{Type: typeName, Key: key, Field: name})
+		}
+	}
+}
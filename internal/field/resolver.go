@@ -7,4 +7,11 @@ type Resolver interface {
 	ResolveScalarValue(p graphql.ResolveParams) (interface{}, error)
 	ResolveObjectValue(p graphql.ResolveParams) (interface{}, error)
 	ResolveArrayValue(p graphql.ResolveParams) (interface{}, error)
+	ResolveConnection(p graphql.ResolveParams) (interface{}, error)
+	// ResolveElements resolves the elements at the positions of the array of the field, in the given order.
+	ResolveElements(p graphql.ResolveParams, positions []int) (interface{}, error)
+	// Lookup returns the value of the key of a resolved object, e.g. to resolve the type of a union member.
+	Lookup(source interface{}, key string) interface{}
+	// Offset returns the position of a resolved object in the data, which identifies it within the data.
+	Offset(source interface{}) (int, bool)
 }
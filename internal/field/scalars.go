@@ -0,0 +1,133 @@
+package field
+
+import (
+	"net/mail"
+	"net/url"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// ScalarDetector types string fields as Scalar when Detect accepts every value observed for the field.
+type ScalarDetector struct {
+	Scalar *graphql.Scalar
+	Detect func(value string) bool
+}
+
+// defaultScalarDetectors are checked in order after the detectors of Config.ScalarDetectors.
+var defaultScalarDetectors = []ScalarDetector{
+	{Scalar: dateTimeScalar, Detect: isDateTime},
+	{Scalar: dateScalar, Detect: isDate},
+	{Scalar: uuidScalar, Detect: isUUID},
+	{Scalar: emailScalar, Detect: isEmail},
+	{Scalar: urlScalar, Detect: isURL},
+}
+
+var (
+	dateTimeScalar = stringScalar("DateTime", "An RFC 3339 timestamp, e.g. \"2024-01-02T15:04:05Z\".", isDateTime)
+	dateScalar     = stringScalar("Date", "An ISO 8601 date, e.g. \"2024-01-02\".", isDate)
+	uuidScalar     = stringScalar("UUID", "A UUID, e.g. \"123e4567-e89b-12d3-a456-426614174000\".", isUUID)
+	emailScalar    = stringScalar("Email", "An email address, e.g. \"john@example.com\".", isEmail)
+	urlScalar      = stringScalar("URL", "An absolute URL, e.g. \"https://example.com/items\".", isURL)
+)
+
+// stringScalar returns a scalar of strings accepted by valid. Other values serialize to null
+// and are rejected as arguments.
+func stringScalar(name, description string, valid func(string) bool) *graphql.Scalar {
+	parse := func(value interface{}) interface{} {
+		if s, ok := value.(string); ok && valid(s) {
+			return s
+		}
+
+		return nil
+	}
+
+	return graphql.NewScalar(graphql.ScalarConfig{
+		Name:        name,
+		Description: description,
+		Serialize:   parse,
+		ParseValue:  parse,
+		ParseLiteral: func(valueAST ast.Value) interface{} {
+			if v, ok := valueAST.(*ast.StringValue); ok {
+				return parse(v.Value)
+			}
+
+			return nil
+		},
+	})
+}
+
+func isDateTime(s string) bool {
+	_, err := time.Parse(time.RFC3339Nano, s)
+	return err == nil
+}
+
+func isDate(s string) bool {
+	_, err := time.Parse(time.DateOnly, s)
+	return err == nil
+}
+
+// isUUID accepts the hyphenated form of any version, e.g. "123e4567-e89b-12d3-a456-426614174000".
+func isUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+
+	for i, r := range s {
+		switch i {
+		case 8, 13, 18, 23:
+			if r != '-' {
+				return false
+			}
+		default:
+			if !isDigit(r) && (r < 'a' || r > 'f') && (r < 'A' || r > 'F') {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// isEmail accepts bare addresses, e.g. "john@example.com" but not "John <john@example.com>".
+func isEmail(s string) bool {
+	addr, err := mail.ParseAddress(s)
+	return err == nil && addr.Address == s
+}
+
+// isURL accepts absolute URLs with a host.
+func isURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && u.Scheme != "" && u.Host != ""
+}
+
+// observeFormats records the detectors rejecting the string value.
+func (f *DefaultFieldFactory) observeFormats(stats *stringStats, value string) {
+	if stats.rejected == nil {
+		stats.rejected = make([]bool, len(f.scalarDetectors))
+	}
+
+	for i, detector := range f.scalarDetectors {
+		if !stats.rejected[i] && !detector.Detect(value) {
+			stats.rejected[i] = true
+		}
+	}
+}
+
+// scalarType returns the scalar of the first detector accepting every string of the key, or nil.
+// The strings are the ones gathered from every object of the parent, not only the one the type is built from.
+func (f *DefaultFieldFactory) scalarType(parent, key string) *graphql.Scalar {
+	stats := f.stringInfo.get(parent, key)
+	if stats.samples == 0 || stats.rejected == nil {
+		return nil
+	}
+
+	for i, detector := range f.scalarDetectors {
+		if !stats.rejected[i] {
+			return detector.Scalar
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package field
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDefaultScalarDetectors verifies which strings every built-in format accepts.
+func TestDefaultScalarDetectors(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{value: "2024-01-02T15:04:05Z", expected: "DateTime"},
+		{value: "2024-01-02T15:04:05.999-07:00", expected: "DateTime"},
+		{value: "2024-01-02", expected: "Date"},
+		{value: "123E4567-E89B-12D3-A456-426614174000", expected: "UUID"},
+		{value: "john.doe+tag@example.com", expected: "Email"},
+		{value: "https://example.com/items?id=1", expected: "URL"},
+		{value: "2024-13-02", expected: ""},
+		{value: "2024-01-02 15:04:05", expected: ""},
+		{value: "123e4567e89b12d3a456426614174000", expected: ""},
+		{value: "John <john@example.com>", expected: ""},
+		{value: "example.com/items", expected: ""},
+		{value: "", expected: ""},
+	}
+
+	for _, test := range tests {
+		var detected string
+		for _, detector := range defaultScalarDetectors {
+			if detector.Detect(test.value) {
+				detected = detector.Scalar.Name()
+
+				break
+			}
+		}
+
+		assert.Equal(t, test.expected, detected, test.value)
+	}
+}
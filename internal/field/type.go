@@ -24,7 +24,7 @@ func (u unionMap) setSubkey(key, subKey string, value bool) {
 }
 
 func (u unionMap) reset() {
-	u = make(unionMap)
+	clear(u)
 }
 
 func (u unionMap) get(key string) (map[string]bool, bool) {
@@ -55,10 +55,145 @@ func (u unionMap) printDebugState() {
 	println("=========================")
 }
 
+type numberMap map[string]map[string]bool // bare key -> subfield -> every number is an Int
+
+func (n numberMap) observe(key, subKey string, isInt bool) {
+	if n[key] == nil {
+		n[key] = make(map[string]bool)
+	}
+
+	prev, ok := n[key][subKey]
+	n[key][subKey] = isInt && (prev || !ok)
+}
+
+func (n numberMap) isInt(key, subKey string) (isInt bool, observed bool) {
+	if n[key] == nil {
+		return false, false
+	}
+
+	isInt, observed = n[key][subKey]
+	return isInt, observed
+}
+
+func (n numberMap) reset() {
+	clear(n)
+}
+
+type variantMap map[string]map[string]map[string]interface{} // union type key -> variant -> merged objects
+
+// observe merges the object into the objects of its variant, so lists sharing a type key
+// get one union with the variants and fields of all of them.
+func (v variantMap) observe(key, variant string, obj map[string]interface{}) {
+	if v[key] == nil {
+		v[key] = make(map[string]map[string]interface{})
+	}
+
+	if merged, ok := v[key][variant]; ok {
+		obj = mergeMaps([]map[string]interface{}{merged, obj})
+	}
+
+	v[key][variant] = obj
+}
+
+func (v variantMap) reset() {
+	clear(v)
+}
+
+type kindMap map[string]map[string]valueKind // type key -> subfield -> observed JSON types
+
+func (k kindMap) observe(key, subKey string, kind valueKind) {
+	if k[key] == nil {
+		k[key] = make(map[string]valueKind)
+	}
+
+	k[key][subKey] |= kind
+}
+
+// kind returns the JSON types observed for the subfield, 0 when only nulls were observed.
+func (k kindMap) kind(key, subKey string) valueKind {
+	return k[key][subKey]
+}
+
+// conflicting reports whether values of different JSON types were observed for the subfield.
+func (k kindMap) conflicting(key, subKey string) bool {
+	return k[key][subKey].conflicting()
+}
+
+func (k kindMap) reset() {
+	clear(k)
+}
+
+// presence counts the samples of a slot and how many of them were present and not null.
+type presence struct {
+	samples int
+	nonNull int
+}
+
+type presenceMap struct {
+	objects map[string]int                  // type key -> observed objects
+	values  map[string]map[string]*presence // type key -> subfield -> observed values
+}
+
+func newPresenceMap() presenceMap {
+	return presenceMap{
+		objects: make(map[string]int),
+		values:  make(map[string]map[string]*presence),
+	}
+}
+
+// observeObject records an object of the type key.
+func (p presenceMap) observeObject(key string) {
+	p.objects[key]++
+}
+
+// observe records a value of the subfield. Empty lists resolve to null, so they count as null.
+func (p presenceMap) observe(key, subKey string, value interface{}) {
+	if p.values[key] == nil {
+		p.values[key] = make(map[string]*presence)
+	}
+
+	if p.values[key][subKey] == nil {
+		p.values[key][subKey] = &presence{}
+	}
+
+	p.values[key][subKey].samples++
+	if list, isList := value.([]interface{}); value != nil && (!isList || len(list) > 0) {
+		p.values[key][subKey].nonNull++
+	}
+}
+
+// required reports whether the subfield is present and not null in every object of the type key.
+func (p presenceMap) required(key, subKey string) bool {
+	v := p.values[key][subKey]
+	return v != nil && v.nonNull > 0 && v.nonNull == p.objects[key]
+}
+
+// elementsRequired reports whether every observed element of the list slot is not null.
+func (p presenceMap) elementsRequired(key, slot string) bool {
+	v := p.values[key][slot]
+	return v != nil && v.nonNull > 0 && v.nonNull == v.samples
+}
+
+func (p presenceMap) reset() {
+	clear(p.objects)
+	clear(p.values)
+}
+
+type fieldKeys map[string]map[string]string // type name -> renamed field -> JSON key
+
+// key returns the JSON key the field of the type was created from.
+func (k fieldKeys) key(typeName, field string) string {
+	if key, ok := k[typeName][field]; ok {
+		return key
+	}
+
+	return field
+}
+
 type gqlTypesCache map[string]*graphql.Object // type name -> GraphQL object
 
 func (g gqlTypesCache) reset() {
-	g = make(gqlTypesCache)
+	clear(g)
 }
 
 func (g gqlTypesCache) set(key string, value *graphql.Object) {
@@ -70,6 +205,36 @@ func (g gqlTypesCache) get(key string) (*graphql.Object, bool) {
 	return v, ok
 }
 
+type inputTypesCache map[string]*graphql.InputObject // type name -> GraphQL input object
+
+func (g inputTypesCache) reset() {
+	clear(g)
+}
+
+func (g inputTypesCache) set(key string, value *graphql.InputObject) {
+	g[key] = value
+}
+
+func (g inputTypesCache) get(key string) (*graphql.InputObject, bool) {
+	v, ok := g[key]
+	return v, ok
+}
+
+type enumTypesCache map[string]*graphql.Enum // type name -> GraphQL enum
+
+func (g enumTypesCache) reset() {
+	clear(g)
+}
+
+func (g enumTypesCache) set(key string, value *graphql.Enum) {
+	g[key] = value
+}
+
+func (g enumTypesCache) get(key string) (*graphql.Enum, bool) {
+	v, ok := g[key]
+	return v, ok
+}
+
 func valueIsObject(value interface{}) (map[string]interface{}, bool) {
 	v, ok := value.(map[string]interface{})
 	return v, ok
@@ -79,3 +244,29 @@ func valueIsArray(value interface{}) ([]interface{}, bool) {
 	v, ok := value.([]interface{})
 	return v, ok
 }
+
+type unionTypesCache map[string]*graphql.Union // type key -> GraphQL union
+
+func (g unionTypesCache) reset() {
+	clear(g)
+}
+
+func (g unionTypesCache) set(key string, value *graphql.Union) {
+	g[key] = value
+}
+
+func (g unionTypesCache) get(key string) (*graphql.Union, bool) {
+	v, ok := g[key]
+	return v, ok
+}
+
+// named reports whether a cached union has the type name.
+func (g unionTypesCache) named(name string) bool {
+	for _, union := range g {
+		if union.Name() == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -43,42 +43,69 @@ package field
 // address : street
 // address : city
 // =========================
+//
+// With NamingByPath the objects are recorded under their paths instead, "parents.address" and "address".
+//
+// Values of the root object and everything below it are recorded as well, see gatherValueInfo.
 func (f *DefaultFieldFactory) GatherUnionInfo(data interface{}) {
+	if root, ok := valueIsObject(data); ok {
+		f.gatherValueInfo(rootKey, root)
+	}
+
+	f.gatherUnionInfo(rootKey, data)
+}
+
+// gatherUnionInfo walks the value stored under the type key, see NamingStrategy.
+func (f *DefaultFieldFactory) gatherUnionInfo(typeKey string, data interface{}) {
 	switch dataType := data.(type) {
 
 	// An object
 	case map[string]interface{}:
-		f.GatherUnionObjectInfo(dataType)
+		f.gatherUnionObjectInfo(typeKey, dataType)
 
 	// An array
 	case []interface{}:
-		f.GatherUnionListInfo(dataType)
+		f.gatherUnionListInfo(typeKey, dataType)
 	}
 }
 
-func (f *DefaultFieldFactory) GatherUnionObjectInfo(data map[string]interface{}) {
+func (f *DefaultFieldFactory) gatherUnionObjectInfo(typeKey string, data map[string]interface{}) {
 	// For each attribute in object
 	for key, value := range data {
-		// If value is an object, record its subkeys under the bare key.
+		childKey := f.childKey(typeKey, key)
+
+		// If value is an object, record its subkeys under the type key of the value.
 		if subObj, ok := valueIsObject(value); ok {
-			f.unionInfo.makeIfNotExists(key)
+			f.unionInfo.makeIfNotExists(childKey)
 
 			for subKey, subVal := range subObj {
 				_, isObj := valueIsObject(subVal)
-				if isObj || !f.unionInfo.subkeyExists(key, subKey) {
-					f.unionInfo.setSubkey(key, subKey, isObj)
+				if isObj || !f.unionInfo.subkeyExists(childKey, subKey) {
+					f.unionInfo.setSubkey(childKey, subKey, isObj)
 					// f.unionInfo.printDebugState()
 				}
 			}
 		}
 
 		// Recurse into the value.
-		f.GatherUnionInfo(value)
+		f.gatherUnionInfo(childKey, value)
 	}
 }
 
-func (f *DefaultFieldFactory) GatherUnionListInfo(data []interface{}) {
+// gatherUnionListInfo walks the elements of a list, they share the type key of the list
+// unless the list becomes a union, see discriminator.
+func (f *DefaultFieldFactory) gatherUnionListInfo(typeKey string, data []interface{}) {
+	discriminator := f.discriminator(data)
+
 	for _, item := range data {
-		f.GatherUnionInfo(item)
+		if discriminator != "" {
+			obj := item.(map[string]interface{})
+			f.variantInfo.observe(typeKey, obj[discriminator].(string), obj)
+			f.gatherUnionInfo(variantKey(typeKey, discriminator, obj), item)
+
+			continue
+		}
+
+		f.gatherUnionInfo(typeKey, item)
 	}
 }
@@ -0,0 +1,152 @@
+package field
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// elementSuffix is appended to the key of a list to record the values of its elements, e.g. "tags[]".
+const elementSuffix = "[]"
+
+// valueKind is a set of the JSON types observed for a key.
+type valueKind uint8
+
+const (
+	kindString valueKind = 1 << iota
+	kindNumber
+	kindBool
+	kindObject
+	kindList
+)
+
+var kindNames = []struct {
+	kind valueKind
+	name string
+}{
+	{kindString, "string"},
+	{kindNumber, "number"},
+	{kindBool, "bool"},
+	{kindObject, "object"},
+	{kindList, "list"},
+}
+
+// conflicting reports whether more than one type was observed.
+func (k valueKind) conflicting() bool {
+	return k&(k-1) != 0
+}
+
+func (k valueKind) names() []string {
+	var names []string
+	for _, kn := range kindNames {
+		if k&kn.kind != 0 {
+			names = append(names, kn.name)
+		}
+	}
+
+	return names
+}
+
+// kindOf returns the kind of a decoded JSON value, 0 for null.
+func kindOf(value interface{}) valueKind {
+	switch value.(type) {
+	case string:
+		return kindString
+	case float64, json.Number:
+		return kindNumber
+	case bool:
+		return kindBool
+	case map[string]interface{}:
+		return kindObject
+	case []interface{}:
+		return kindList
+	default:
+		return 0
+	}
+}
+
+// gatherValueInfo records what was observed for every key of every object: the JSON types of its values,
+// see conflicts, whether all of its numbers are Ints, see createScalarField, its strings, see enumType,
+// and how often it is present and not null, see nonNullType.
+// Objects are recorded under their type key, elements of a list of objects under the type key of the list
+// and the elements of other lists under the key of the list itself.
+//
+// For the JSON data below "items: price" is a Float, "items: count" is an Int and "items: size" conflicts:
+//
+//	{
+//	    "items": [
+//	        {"price": 1, "count": 3, "size": "XL"},
+//	        {"price": 2.5, "count": 4, "size": 42}
+//	    ]
+//	}
+func (f *DefaultFieldFactory) gatherValueInfo(parent string, data map[string]interface{}) {
+	f.presenceInfo.observeObject(parent)
+
+	for key, value := range data {
+		slot := escapeKey(key)
+		f.presenceInfo.observe(parent, slot, value)
+		f.gatherValue(parent, key, slot, value)
+	}
+}
+
+// gatherValue records the value of the key of the parent object. The slot is the escaped key for the value
+// and the escaped key with an elementSuffix per level for the elements of lists, see escapeKey.
+func (f *DefaultFieldFactory) gatherValue(parent, key, slot string, value interface{}) {
+	if kind := kindOf(value); kind != 0 {
+		f.kindInfo.observe(parent, slot, kind)
+	}
+
+	switch v := value.(type) {
+	case string:
+		f.observeString(parent, key, v)
+	case float64, json.Number:
+		f.numberInfo.observe(parent, key, numberIsInt(v))
+	case map[string]interface{}:
+		f.gatherValueInfo(f.childKey(parent, key), v)
+	case []interface{}:
+		discriminator := f.discriminator(v)
+
+		for _, item := range v {
+			f.presenceInfo.observe(parent, slot+elementSuffix, item)
+
+			if discriminator != "" {
+				obj := item.(map[string]interface{})
+				f.gatherValueInfo(variantKey(f.childKey(parent, key), discriminator, obj), obj)
+
+				continue
+			}
+
+			f.gatherValue(parent, key, slot+elementSuffix, item)
+		}
+	}
+}
+
+// Conflict is a key whose values have different JSON types. It is typed as the JSON scalar.
+type Conflict struct {
+	Path  string   // type key and key of the value, with "[]" for list elements, e.g. "items.size"
+	Types []string // observed JSON types
+}
+
+// conflicts returns the conflicting keys recorded by gatherValueInfo ordered by path.
+func (f *DefaultFieldFactory) conflicts() []Conflict {
+	var conflicts []Conflict
+	for parent, slots := range f.kindInfo {
+		for slot, kind := range slots {
+			if !kind.conflicting() {
+				continue
+			}
+
+			path := slot
+			if parent != rootKey {
+				path = parent + pathSeparator + slot
+			}
+
+			conflicts = append(conflicts, Conflict{Path: path, Types: kind.names()})
+		}
+	}
+
+	sort.Slice(conflicts, func(i, j int) bool {
+		return conflicts[i].Path < conflicts[j].Path
+	})
+
+	return conflicts
+}
@@ -2,9 +2,13 @@ package api
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/niklod/json-to-graphql-go/internal/builder"
@@ -12,21 +16,72 @@ import (
 	"github.com/niklod/json-to-graphql-go/pkg/data"
 	"github.com/niklod/json-to-graphql-go/pkg/handler"
 	"github.com/niklod/json-to-graphql-go/pkg/resolver"
+	"github.com/niklod/json-to-graphql-go/pkg/snapshot"
+	"github.com/niklod/json-to-graphql-go/pkg/watcher"
 )
 
 type App struct {
-	Handler         http.Handler
+	Handler http.Handler
+	// StatusHandler serves the Status as JSON.
+	StatusHandler http.Handler
+	// SchemaHandler serves the SDL of the schema, see SchemaSDL.
+	SchemaHandler   http.Handler
 	internalHandler *handler.GraphQLHandler
 	jsonProvider    JsonProvider
 	schemaBuilder   SchemaBuilder
 	resolver        Resolver
 	logger          *slog.Logger
+
+	rejectBreakingChanges bool
+
+	updateMu    sync.Mutex
+	attempted   string
+	metrics     reloadMetrics
+	lastAttempt atomic.Pointer[ReloadAttempt]
+	report      atomic.Pointer[SchemaReport]
+	sdl         atomic.Pointer[printedSchema]
 }
 
+// SchemaOptions configures how the schema is inferred from the data.
+// Its Resolver defaults to the resolver of the app.
+type SchemaOptions = field.Config
+
+// SchemaReport describes how the data was adjusted to fit into the schema, e.g. renamed keys.
+type SchemaReport = field.Report
+
+// SchemaRename maps a JSON key to the name of its GraphQL field.
+type SchemaRename = field.Rename
+
+// NamingStrategy selects how inferred object types are named, see SchemaOptions.Naming.
+type NamingStrategy = field.NamingStrategy
+
+const (
+	NamingByKey  = field.NamingByKey
+	NamingByPath = field.NamingByPath
+)
+
+// ScalarDetector types string fields as a custom scalar, see SchemaOptions.ScalarDetectors.
+type ScalarDetector = field.ScalarDetector
+
+// Relation links two top-level lists of objects, see SchemaOptions.Relations and LoadRelations.
+type Relation = field.Relation
+
 type Config struct {
 	JSONProvider JsonProvider
 	Resolver     Resolver
 	SchemBuilder SchemaBuilder
+	// Schema is used when SchemBuilder is not set.
+	Schema SchemaOptions
+	// RejectBreakingChanges keeps serving the current snapshot when the reloaded data would
+	// remove or retype any existing field, argument, enum value or union member, see builder.BreakingChanges.
+	RejectBreakingChanges bool
+	// ErrorCodes adds "extensions.code" to the errors of GraphQL responses.
+	ErrorCodes bool
+	// DisablePlayground stops serving the playground page to browsers,
+	// e.g. in shared environments.
+	DisablePlayground bool
+	// Logger defaults to a debug level text logger writing to stdout.
+	Logger *slog.Logger
 }
 
 func New(config Config) (*App, error) {
@@ -35,80 +90,297 @@ func New(config Config) (*App, error) {
 		jsonProvider = data.NewJSONProvider()
 	}
 
-	rawJson, err := jsonProvider.GetRawJson()
-	if err != nil {
-		return nil, err
-	}
-
 	dataResolver := config.Resolver
 	if dataResolver == nil {
-		dataResolver = resolver.NewJSONResolver(rawJson)
-	}
-
-	fieldFactory, err := field.NewDefaultFieldFactory(field.Config{
-		Resolver: dataResolver,
-	})
-	if err != nil {
-		return nil, err
+		dataResolver = resolver.NewJSONResolver(nil)
 	}
 
 	schemaBuilder := config.SchemBuilder
 	if schemaBuilder == nil {
+		schemaOptions := config.Schema
+		if schemaOptions.Resolver == nil {
+			schemaOptions.Resolver = dataResolver
+		}
+
+		fieldFactory, err := field.NewDefaultFieldFactory(schemaOptions)
+		if err != nil {
+			return nil, err
+		}
+
 		schemaBuilder = builder.NewGraphQLSchemaBuilder(fieldFactory)
 	}
 
 	handler := handler.NewGraphQLHandler(schemaBuilder)
 
-	return &App{
-		internalHandler: handler,
-		Handler:         handler,
-		jsonProvider:    jsonProvider,
-		schemaBuilder:   schemaBuilder,
-		resolver:        dataResolver,
-		logger:          slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
-	}, nil
+	logger := config.Logger
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	}
+
+	app := &App{
+		internalHandler:       handler,
+		Handler:               handler,
+		jsonProvider:          jsonProvider,
+		schemaBuilder:         schemaBuilder,
+		resolver:              dataResolver,
+		logger:                logger,
+		rejectBreakingChanges: config.RejectBreakingChanges,
+	}
+	app.StatusHandler = http.HandlerFunc(app.serveStatus)
+	app.SchemaHandler = http.HandlerFunc(app.serveSchema)
+	handler.SetExtensionsFunc(app.responseExtensions)
+	handler.SetErrorCodes(config.ErrorCodes)
+	handler.SetPlayground(!config.DisablePlayground)
+
+	// Serve the data right away instead of waiting for the first background update.
+	if err := app.SchemaUpdate(); err != nil {
+		return nil, err
+	}
+
+	return app, nil
 }
 
+// SchemaUpdate reloads the data and rebuilds the schema.
+// The rebuild is skipped when the content hash of the data equals the last attempted one,
+// so neither unchanged nor known broken data is processed twice.
+// On failure the previous snapshot keeps being served.
 func (a *App) SchemaUpdate() error {
-	structuredJson, err := a.jsonProvider.GetJsonData()
+	a.updateMu.Lock()
+	defer a.updateMu.Unlock()
+
+	version, result, err := a.schemaUpdate()
+	a.metrics.record(result)
+
+	if result != ReloadSkipped {
+		a.recordAttempt(version, result, err)
+	}
+
+	return err
+}
+
+func (a *App) schemaUpdate() (string, ReloadResult, error) {
+	current, err := a.readSnapshot()
 	if err != nil {
-		return err
+		return "", ReloadFailed, err
 	}
 
-	rawJson, err := a.jsonProvider.GetRawJson()
+	if current.Version == a.attempted {
+		a.logger.Debug("data unchanged, schema update skipped", slog.String("version", current.Version))
+
+		return current.Version, ReloadSkipped, nil
+	}
+
+	a.attempted = current.Version
+
+	structuredJson, err := a.decodeSnapshot(current)
 	if err != nil {
-		return err
+		return current.Version, ReloadFailed, err
 	}
 
 	schema, err := a.schemaBuilder.BuildSchema(structuredJson)
 	if err != nil {
-		return err
+		return current.Version, ReloadFailed, err
 	}
 
-	a.internalHandler.UpdateSchema(schema)
-	a.resolver.UpdateJsonData(rawJson)
+	if previous := a.internalHandler.Snapshot(); a.rejectBreakingChanges && previous != nil {
+		if changes := builder.BreakingChanges(previous.Schema, schema); len(changes) > 0 {
+			return current.Version, ReloadRejected, fmt.Errorf("%w: %s", ErrBreakingChange, strings.Join(changes, "; "))
+		}
+	}
+
+	a.resolver.UpdateJsonData(current.Raw)
 
-	a.logger.Debug("schema updated")
+	next := &snapshot.Snapshot{
+		Schema:   schema,
+		Data:     current.Raw,
+		Version:  current.Version,
+		LoadedAt: time.Now(),
+	}
 
-	return nil
+	// The data is parsed before requests are pinned to it.
+	if parser, ok := a.resolver.(ParsingResolver); ok {
+		next.Document = parser.Parse(current.Raw)
+	}
+
+	// Schema and data are swapped together, in-flight requests keep the previous snapshot.
+	a.internalHandler.UpdateSnapshot(next)
+
+	a.metrics.version.Store(current.Version)
+
+	if reporter, ok := a.schemaBuilder.(ReportingSchemaBuilder); ok {
+		report := reporter.Report()
+		a.report.Store(&report)
+
+		for _, conflict := range report.Conflicts {
+			a.logger.Warn("conflicting json types, typed as JSON",
+				slog.String("path", conflict.Path), slog.String("types", strings.Join(conflict.Types, ", ")))
+		}
+
+		for _, relation := range report.Relations {
+			a.logger.Debug("relation added",
+				slog.String("from", relation.From), slog.String("key", relation.Key), slog.String("to", relation.To),
+				slog.String("field", relation.Field), slog.String("reverse", relation.Reverse))
+		}
+
+		for _, rejected := range report.RejectedRelations {
+			a.logger.Warn("relation not added",
+				slog.String("from", rejected.From), slog.String("key", rejected.Key), slog.String("to", rejected.To),
+				slog.String("reason", rejected.Reason))
+		}
+
+		for _, rejected := range report.RejectedEntityKeys {
+			a.logger.Warn("entity key not used",
+				slog.String("list", rejected.List), slog.String("key", rejected.Key), slog.String("reason", rejected.Reason))
+		}
+
+		for _, rename := range report.RenamedTypes {
+			a.logger.Debug("type renamed, its name was taken", slog.String("key", rename.Key), slog.String("type", rename.Type))
+		}
+
+		for _, rename := range report.Renamed {
+			a.logger.Debug("json key renamed",
+				slog.String("type", rename.Type), slog.String("key", rename.Key), slog.String("field", rename.Field))
+		}
+	}
+
+	a.logger.Info("schema updated", slog.String("version", current.Version))
+
+	return current.Version, ReloadApplied, nil
 }
 
+// readSnapshot reads the data once when the provider supports it.
+// Other providers are hashed by their raw data.
+func (a *App) readSnapshot() (*data.Snapshot, error) {
+	if provider, ok := a.jsonProvider.(SnapshotProvider); ok {
+		return provider.GetSnapshot()
+	}
+
+	rawJson, err := a.jsonProvider.GetRawJson()
+	if err != nil {
+		return nil, err
+	}
+
+	return data.NewSnapshot(rawJson), nil
+}
+
+func (a *App) decodeSnapshot(snapshot *data.Snapshot) (map[string]interface{}, error) {
+	if _, ok := a.jsonProvider.(SnapshotProvider); ok {
+		return snapshot.Decode()
+	}
+
+	return a.jsonProvider.GetJsonData()
+}
+
+// ReloadStats returns the counters of schema reload outcomes.
+func (a *App) ReloadStats() ReloadStats {
+	return a.metrics.stats()
+}
+
+// StartBackgroundSchemaUpdate rebuilds the schema every interval.
 func (a *App) StartBackgroundSchemaUpdate(ctx context.Context, interval time.Duration) {
-	go func() {
-		ticker := time.NewTicker(interval)
-		defer ticker.Stop()
-
-		for {
-			select {
-			case <-ctx.Done():
-				a.logger.Debug("context done, stopping schema update")
-
-				return
-			case <-ticker.C:
-				if err := a.SchemaUpdate(); err != nil {
-					a.logger.Error("failed to update schema", slog.Any("error", err))
-				}
+	go a.pollSchemaUpdates(ctx, interval)
+}
+
+// StartWatchSchemaUpdate rebuilds the schema when the data files change.
+// Bursts of events (e.g. an editor writing a temp file and renaming it) are
+// collapsed into one update once no event arrived for the debounce period.
+// The schema is also polled every pollInterval, so changes the events miss
+// (e.g. on network file systems) are still picked up; unchanged data is skipped by its hash.
+// If the provider or the platform doesn't support file system events, the schema is only polled.
+func (a *App) StartWatchSchemaUpdate(ctx context.Context, debounce, pollInterval time.Duration) {
+	w, err := a.newWatcher()
+	if err != nil {
+		a.logger.Warn("failed to watch data files, falling back to polling",
+			slog.Any("error", err),
+			slog.Duration("interval", pollInterval),
+		)
+		a.StartBackgroundSchemaUpdate(ctx, pollInterval)
+
+		return
+	}
+
+	go a.watchSchemaUpdates(ctx, w, debounce, pollInterval)
+}
+
+func (a *App) newWatcher() (*watcher.Watcher, error) {
+	provider, ok := a.jsonProvider.(WatchableJsonProvider)
+	if !ok {
+		return nil, ErrProviderNotWatchable
+	}
+
+	dirs, err := provider.WatchDirs()
+	if err != nil {
+		return nil, err
+	}
+
+	return watcher.New(dirs, provider.Matches)
+}
+
+func (a *App) watchSchemaUpdates(ctx context.Context, w *watcher.Watcher, debounce, pollInterval time.Duration) {
+	defer w.Close()
+
+	timer := time.NewTimer(debounce)
+	timer.Stop()
+	defer timer.Stop()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	events := w.Events()
+
+	for {
+		select {
+		case <-ctx.Done():
+			a.logger.Debug("context done, stopping schema watcher")
+
+			return
+		case path, ok := <-events:
+			if !ok {
+				a.logger.Warn("data file watcher stopped, falling back to polling", slog.Duration("interval", pollInterval))
+				// A nil channel blocks, the ticker keeps polling.
+				events = nil
+
+				continue
 			}
+
+			a.logger.Debug("data file changed", slog.String("path", path))
+			timer.Reset(debounce)
+		case <-timer.C:
+			a.updateSchema()
+		case <-ticker.C:
+			a.updateSchema()
 		}
-	}()
+	}
+}
+
+func (a *App) pollSchemaUpdates(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			a.logger.Debug("context done, stopping schema update")
+
+			return
+		case <-ticker.C:
+			a.updateSchema()
+		}
+	}
+}
+
+func (a *App) updateSchema() {
+	if err := a.SchemaUpdate(); err != nil {
+		a.logger.Error("failed to update schema", slog.Any("error", err))
+	}
+}
+
+// SchemaReport returns the report of the schema being served.
+// It is empty when the schema builder doesn't implement ReportingSchemaBuilder.
+func (a *App) SchemaReport() SchemaReport {
+	if report := a.report.Load(); report != nil {
+		return *report
+	}
+
+	return SchemaReport{}
 }
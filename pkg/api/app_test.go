@@ -0,0 +1,174 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/niklod/json-to-graphql-go/pkg/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestApp(t *testing.T, content string) (*App, string) {
+	t.Helper()
+
+	return newTestAppWithConfig(t, content, Config{})
+}
+
+func newTestAppWithConfig(t *testing.T, content string, config Config) (*App, string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "data.json")
+	writeData(t, path, content)
+
+	config.JSONProvider = data.NewJSONProviderFromSource(path)
+	config.Logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	app, err := New(config)
+	assert.NoError(t, err, "App creation should not error")
+
+	return app, path
+}
+
+func writeData(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
+
+// TestSchemaUpdateSkipsUnchangedData verifies that reloads are skipped by content hash.
+func TestSchemaUpdateSkipsUnchangedData(t *testing.T) {
+	app, path := newTestApp(t, `{"user": {"name": "John"}}`)
+
+	assert.NoError(t, app.SchemaUpdate())
+
+	stats := app.ReloadStats()
+	assert.Equal(t, uint64(1), stats.Applied, "Initial load should be applied")
+	assert.Equal(t, uint64(1), stats.Skipped, "Update of unchanged data should be skipped")
+	assert.Equal(t, ReloadSkipped, stats.LastResult)
+
+	version := stats.Version
+	writeData(t, path, `{"user": {"name": "Jane"}}`)
+
+	assert.NoError(t, app.SchemaUpdate())
+
+	stats = app.ReloadStats()
+	assert.Equal(t, uint64(2), stats.Applied, "Changed data should be applied")
+	assert.NotEqual(t, version, stats.Version, "Version should follow the content")
+
+	writeData(t, path, `{"user": `)
+
+	assert.Error(t, app.SchemaUpdate())
+
+	stats = app.ReloadStats()
+	assert.Equal(t, uint64(1), stats.Failed, "Broken data should fail")
+	assert.Equal(t, ReloadFailed, stats.LastResult)
+}
+
+// TestWatchKeepsPolling verifies that the schema is polled while the data files are watched,
+// so changes missed by file system events are picked up.
+func TestWatchKeepsPolling(t *testing.T) {
+	app, path := newTestApp(t, `{"user": {"name": "John"}}`)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The debounce period never ends, only polling can pick up the change.
+	app.StartWatchSchemaUpdate(ctx, time.Hour, 10*time.Millisecond)
+	writeData(t, path, `{"user": {"name": "Jane"}}`)
+
+	assert.Eventually(t, func() bool {
+		return app.ReloadStats().Applied == 2
+	}, 5*time.Second, 10*time.Millisecond, "Polling should apply the changed data")
+}
+
+// TestBrokenDataKeepsLastGoodSnapshot verifies that invalid data is reported but not served.
+func TestBrokenDataKeepsLastGoodSnapshot(t *testing.T) {
+	app, path := newTestApp(t, `{"user": {"name": "John"}}`)
+	good := app.Status()
+
+	writeData(t, path, `{"user": {"name": `)
+	assert.Error(t, app.SchemaUpdate())
+
+	status := app.Status()
+	assert.True(t, status.Stale, "Status should be stale")
+	assert.Equal(t, good.Version, status.Version, "Last good snapshot should be served")
+	assert.Equal(t, ReloadFailed, status.LastAttempt.Result)
+	assert.NotEmpty(t, status.LastAttempt.Error)
+
+	code, res := query(app.Handler, `{ user { name } }`)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "John", res["data"].(map[string]interface{})["user"].(map[string]interface{})["name"])
+
+	extensions := res["extensions"].(map[string]interface{})
+	assert.Equal(t, good.Version, extensions["snapshot"].(map[string]interface{})["version"])
+	assert.Equal(t, status.LastAttempt.Error, extensions["reload"].(map[string]interface{})["error"])
+
+	rec := httptest.NewRecorder()
+	app.StatusHandler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/status", nil))
+
+	var served Status
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &served))
+	assert.True(t, served.Stale, "Status endpoint should report stale data")
+
+	writeData(t, path, `{"user": {"name": "Jane"}}`)
+	assert.NoError(t, app.SchemaUpdate())
+	assert.False(t, app.Status().Stale, "Status should recover after a good reload")
+}
+
+// TestBreakingChangeGuard verifies that schemas dropping fields are refused when enabled.
+func TestBreakingChangeGuard(t *testing.T) {
+	app, path := newTestAppWithConfig(t, `{"user": {"name": "John", "age": 30}}`, Config{RejectBreakingChanges: true})
+
+	writeData(t, path, `{"user": {"name": "John"}}`)
+	err := app.SchemaUpdate()
+	assert.ErrorIs(t, err, ErrBreakingChange)
+	assert.Contains(t, err.Error(), "user.age")
+
+	status := app.Status()
+	assert.True(t, status.Stale, "Status should be stale")
+	assert.Equal(t, ReloadRejected, status.LastAttempt.Result)
+	assert.Equal(t, uint64(1), status.Reloads.Rejected)
+
+	code, _ := query(app.Handler, `{ user { age } }`)
+	assert.Equal(t, http.StatusOK, code, "Old schema should still be served")
+
+	writeData(t, path, `{"user": {"name": "John", "age": 30, "email": "john@example.com"}}`)
+	assert.NoError(t, app.SchemaUpdate(), "Additive changes should be applied")
+}
+
+// TestSchemaReport verifies that the report of the served schema lists renamed keys.
+func TestSchemaReport(t *testing.T) {
+	app, path := newTestApp(t, `{"user-name": "John"}`)
+
+	assert.Equal(t, []SchemaRename{{Type: "RootQuery", Key: "user-name", Field: "userName"}}, app.SchemaReport().Renamed)
+
+	writeData(t, path, `{"userName": "John"}`)
+	assert.NoError(t, app.SchemaUpdate())
+	assert.Empty(t, app.SchemaReport().Renamed, "Report should follow the served schema")
+}
+
+// TestSchemaHandler verifies that the SDL of the served schema follows reloads.
+func TestSchemaHandler(t *testing.T) {
+	app, path := newTestApp(t, `{"user": {"name": "John"}}`)
+
+	rec := httptest.NewRecorder()
+	app.SchemaHandler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/schema.graphql", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/plain; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), "type userObject {\n  name: String\n}")
+	assert.Equal(t, app.SchemaSDL(), rec.Body.String())
+
+	writeData(t, path, `{"user": {"name": "Jane", "age": 30}}`)
+	assert.NoError(t, app.SchemaUpdate())
+	assert.Contains(t, app.SchemaSDL(), "type userObject {\n  age: Int\n  name: String\n}")
+}
@@ -0,0 +1,9 @@
+package api
+
+import "errors"
+
+var (
+	ErrProviderNotWatchable = errors.New("json provider doesn't support watching")
+	ErrBreakingChange       = errors.New("reloaded data removes or retypes parts of the schema")
+	ErrInvalidRelation      = errors.New("relation needs from and key")
+)
@@ -1,12 +1,25 @@
 package api
 
-import "github.com/graphql-go/graphql"
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/niklod/json-to-graphql-go/pkg/data"
+)
 
 type Resolver interface {
 	UpdateJsonData(jsonData []byte)
 	ResolveScalarValue(p graphql.ResolveParams) (interface{}, error)
 	ResolveObjectValue(p graphql.ResolveParams) (interface{}, error)
 	ResolveArrayValue(p graphql.ResolveParams) (interface{}, error)
+	ResolveConnection(p graphql.ResolveParams) (interface{}, error)
+	ResolveElements(p graphql.ResolveParams, positions []int) (interface{}, error)
+	Lookup(source interface{}, key string) interface{}
+	Offset(source interface{}) (int, bool)
+}
+
+// ParsingResolver is a Resolver that parses the data once per snapshot, when the snapshot is built.
+type ParsingResolver interface {
+	Resolver
+	Parse(jsonData []byte) interface{}
 }
 
 type JsonProvider interface {
@@ -14,6 +27,24 @@ type JsonProvider interface {
 	GetRawJson() ([]byte, error)
 }
 
+// SnapshotProvider is a JsonProvider that reads the data and its version at once.
+type SnapshotProvider interface {
+	GetSnapshot() (*data.Snapshot, error)
+}
+
+// WatchableJsonProvider is a JsonProvider whose files can be watched for changes.
+type WatchableJsonProvider interface {
+	JsonProvider
+	WatchDirs() ([]string, error)
+	Matches(path string) bool
+}
+
 type SchemaBuilder interface {
 	BuildSchema(jsonData map[string]interface{}) (*graphql.Schema, error)
 }
+
+// ReportingSchemaBuilder is a SchemaBuilder that describes how it adjusted the data of the last schema.
+type ReportingSchemaBuilder interface {
+	SchemaBuilder
+	Report() SchemaReport
+}
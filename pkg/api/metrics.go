@@ -0,0 +1,67 @@
+package api
+
+import "sync/atomic"
+
+// ReloadResult is the outcome of a schema reload.
+type ReloadResult string
+
+const (
+	ReloadApplied ReloadResult = "applied"
+	ReloadSkipped ReloadResult = "skipped"
+	ReloadFailed  ReloadResult = "failed"
+	// ReloadRejected means the data was valid but the schema would break existing queries.
+	ReloadRejected ReloadResult = "rejected"
+)
+
+// ReloadStats counts schema reload outcomes since the app was created.
+type ReloadStats struct {
+	Applied    uint64       `json:"applied"`
+	Skipped    uint64       `json:"skipped"`
+	Failed     uint64       `json:"failed"`
+	Rejected   uint64       `json:"rejected"`
+	LastResult ReloadResult `json:"lastResult,omitempty"`
+	Version    string       `json:"version,omitempty"`
+}
+
+type reloadMetrics struct {
+	applied    atomic.Uint64
+	skipped    atomic.Uint64
+	failed     atomic.Uint64
+	rejected   atomic.Uint64
+	lastResult atomic.Value // ReloadResult
+	version    atomic.Value // string
+}
+
+func (m *reloadMetrics) record(result ReloadResult) {
+	switch result {
+	case ReloadApplied:
+		m.applied.Add(1)
+	case ReloadSkipped:
+		m.skipped.Add(1)
+	case ReloadFailed:
+		m.failed.Add(1)
+	case ReloadRejected:
+		m.rejected.Add(1)
+	}
+
+	m.lastResult.Store(result)
+}
+
+func (m *reloadMetrics) stats() ReloadStats {
+	stats := ReloadStats{
+		Applied:  m.applied.Load(),
+		Skipped:  m.skipped.Load(),
+		Failed:   m.failed.Load(),
+		Rejected: m.rejected.Load(),
+	}
+
+	if v, ok := m.lastResult.Load().(ReloadResult); ok {
+		stats.LastResult = v
+	}
+
+	if v, ok := m.version.Load().(string); ok {
+		stats.Version = v
+	}
+
+	return stats
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// LoadRelations reads relations from a JSON file, e.g.
+//
+//	[
+//	    {"from": "orders", "key": "buyerId", "to": "users", "field": "buyer", "reverse": "purchases"},
+//	    {"from": "orders", "key": "productId", "to": ""}
+//	]
+//
+// The second relation drops the inferred one of orders[].productId.
+func LoadRelations(path string) ([]Relation, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+
+	var relations []Relation
+	if err := decoder.Decode(&relations); err != nil {
+		return nil, fmt.Errorf("relations %s: %w", path, err)
+	}
+
+	for i, relation := range relations {
+		if relation.From == "" || relation.Key == "" {
+			return nil, fmt.Errorf("relations %s: entry %d: %w", path, i, ErrInvalidRelation)
+		}
+	}
+
+	return relations, nil
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLoadRelations verifies that relations are read from a file and applied to the schema.
+func TestLoadRelations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "relations.json")
+	writeData(t, path, `[{"from": "orders", "key": "buyerId", "to": "users", "field": "buyer"}]`)
+
+	relations, err := LoadRelations(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []Relation{{From: "orders", Key: "buyerId", To: "users", Field: "buyer"}}, relations)
+
+	app, _ := newTestAppWithConfig(t, `{
+        "users": [{"id": 1, "name": "John"}],
+        "orders": [{"id": 7, "buyerId": 1}]
+    }`, Config{Schema: SchemaOptions{Relations: relations}})
+
+	status, body := query(app.Handler, `{ order(id: 7) { buyer { name orders { id } } } }`)
+	assert.Equal(t, 200, status)
+	assert.Equal(t, map[string]interface{}{
+		"order": map[string]interface{}{
+			"buyer": map[string]interface{}{"name": "John", "orders": []interface{}{map[string]interface{}{"id": "7"}}},
+		},
+	}, body["data"])
+
+	writeData(t, path, `[{"key": "buyerId", "to": "users"}]`)
+	_, err = LoadRelations(path)
+	assert.ErrorIs(t, err, ErrInvalidRelation)
+
+	writeData(t, path, `[{"from": "orders", "key": "buyerId", "target": "users"}]`)
+	_, err = LoadRelations(path)
+	assert.Error(t, err, "Unknown fields should be rejected")
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/graphql-go/graphql"
+	"github.com/niklod/json-to-graphql-go/internal/builder"
+)
+
+// printedSchema is the SDL of a served schema, printed once per schema.
+type printedSchema struct {
+	schema *graphql.Schema
+	sdl    string
+}
+
+// PrintSchema returns the SDL of a schema built by the schema builder. Types, fields, arguments
+// and enum values are sorted by name, so unchanged data prints the same SDL.
+// Federation subgraphs print the SDL served by _service.
+func PrintSchema(schema *graphql.Schema) string {
+	return builder.PrintSchema(schema)
+}
+
+// SchemaSDL returns the SDL of the schema being served, "" before the first schema is built.
+func (a *App) SchemaSDL() string {
+	current := a.internalHandler.Snapshot()
+	if current == nil {
+		return ""
+	}
+
+	if printed := a.sdl.Load(); printed != nil && printed.schema == current.Schema {
+		return printed.sdl
+	}
+
+	printed := &printedSchema{schema: current.Schema, sdl: PrintSchema(current.Schema)}
+	a.sdl.Store(printed)
+
+	return printed.sdl
+}
+
+func (a *App) serveSchema(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, _ = w.Write([]byte(a.SchemaSDL()))
+}
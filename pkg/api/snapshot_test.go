@@ -0,0 +1,114 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// generation returns data whose "count" always equals the number of items.
+// Odd generations also change the shape of the items, so the schema changes too.
+func generation(i int) string {
+	n := i%5 + 1
+
+	items := make([]string, n)
+	for j := range items {
+		if i%2 == 1 {
+			items[j] = fmt.Sprintf(`{"n": %d, "extra": true}`, n)
+		} else {
+			items[j] = fmt.Sprintf(`{"n": %d}`, n)
+		}
+	}
+
+	return fmt.Sprintf(`{"count": %d, "items": [%s]}`, n, strings.Join(items, ","))
+}
+
+// replaceFile writes the file through a rename so that readers never see partial content.
+func replaceFile(path, content string) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(content), 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
+
+func query(handler http.Handler, q string) (int, map[string]interface{}) {
+	body, _ := json.Marshal(map[string]string{"query": q})
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body))))
+
+	var res map[string]interface{}
+	_ = json.Unmarshal(rec.Body.Bytes(), &res)
+
+	return rec.Code, res
+}
+
+// TestReloadDuringQueries hammers reloads while queries are served.
+// Every response must be consistent with a single generation of the data.
+// Run with -race to also check that the swap is synchronized.
+func TestReloadDuringQueries(t *testing.T) {
+	app, path := newTestApp(t, generation(0))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reloads := make(chan error, 1)
+	go func() {
+		defer close(reloads)
+
+		for i := 1; ctx.Err() == nil; i++ {
+			if err := replaceFile(path, generation(i)); err != nil {
+				reloads <- err
+
+				return
+			}
+
+			if err := app.SchemaUpdate(); err != nil {
+				reloads <- err
+
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := 0; i < 200; i++ {
+				code, res := query(app.Handler, `{ count items { n } }`)
+				if !assert.Equal(t, http.StatusOK, code, "Query should succeed") {
+					return
+				}
+
+				data := res["data"].(map[string]interface{})
+				count := data["count"].(float64)
+				items := data["items"].([]interface{})
+
+				if !assert.Equal(t, int(count), len(items), "Count and items should come from the same data") {
+					return
+				}
+
+				for _, item := range items {
+					assert.Equal(t, count, item.(map[string]interface{})["n"], "Items should come from the same data")
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+	cancel()
+
+	assert.NoError(t, <-reloads, "Reloads should not error")
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/niklod/json-to-graphql-go/pkg/snapshot"
+)
+
+// ReloadAttempt describes the most recent reload that read new data.
+type ReloadAttempt struct {
+	At      time.Time    `json:"at"`
+	Version string       `json:"version,omitempty"`
+	Result  ReloadResult `json:"result"`
+	Error   string       `json:"error,omitempty"`
+}
+
+// Status describes the snapshot being served and the most recent reload attempt.
+// Stale is set when the last attempt failed and an older snapshot is still served.
+type Status struct {
+	Version     string         `json:"version"`
+	LoadedAt    time.Time      `json:"loadedAt"`
+	Stale       bool           `json:"stale"`
+	LastAttempt *ReloadAttempt `json:"lastAttempt,omitempty"`
+	Reloads     ReloadStats    `json:"reloads"`
+}
+
+// Status returns the current serving and reload status.
+func (a *App) Status() Status {
+	status := Status{
+		LastAttempt: a.lastAttempt.Load(),
+		Reloads:     a.ReloadStats(),
+	}
+
+	if current := a.internalHandler.Snapshot(); current != nil {
+		status.Version = current.Version
+		status.LoadedAt = current.LoadedAt
+	}
+
+	status.Stale = status.LastAttempt != nil && status.LastAttempt.Error != ""
+
+	return status
+}
+
+func (a *App) recordAttempt(version string, result ReloadResult, err error) {
+	attempt := &ReloadAttempt{
+		At:      time.Now(),
+		Version: version,
+		Result:  result,
+	}
+
+	if err != nil {
+		attempt.Error = err.Error()
+	}
+
+	a.lastAttempt.Store(attempt)
+}
+
+func (a *App) serveStatus(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(a.Status()); err != nil {
+		http.Error(w, "failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// responseExtensions describes the snapshot a response was served from,
+// together with the reload error while the served data is stale.
+func (a *App) responseExtensions(s *snapshot.Snapshot) map[string]interface{} {
+	extensions := map[string]interface{}{
+		"snapshot": map[string]interface{}{
+			"version":  s.Version,
+			"loadedAt": s.LoadedAt,
+		},
+	}
+
+	if attempt := a.lastAttempt.Load(); attempt != nil && attempt.Error != "" {
+		extensions["reload"] = attempt
+	}
+
+	return extensions
+}
@@ -0,0 +1,9 @@
+package data
+
+import "errors"
+
+var (
+	ErrNoDataFiles       = errors.New("no json files found for data source")
+	ErrDuplicateDataFile = errors.New("several json files map to the same root field")
+	ErrTrailingData      = errors.New("unexpected data after the top-level json value")
+)
@@ -2,35 +2,183 @@ package data
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 )
 
+// DefaultSource is the data source used when none is configured.
+const DefaultSource = "./data.json"
+
+// JsonProvider reads JSON data from a file, a glob pattern or a directory.
+//
+// A single file is exposed as is: its top-level keys become root query fields.
+// A glob pattern or a directory is exposed as one object where every matched
+// file becomes a root field named after its basename, e.g. a folder with
+// users.json and orders.json produces the "users" and "orders" fields.
 type JsonProvider struct {
+	source string
 }
 
+// NewJSONProvider creates a provider reading DefaultSource.
 func NewJSONProvider() *JsonProvider {
-	return &JsonProvider{}
+	return NewJSONProviderFromSource(DefaultSource)
+}
+
+// NewJSONProviderFromSource creates a provider for the given file path, glob pattern or directory.
+func NewJSONProviderFromSource(source string) *JsonProvider {
+	if source == "" {
+		source = DefaultSource
+	}
+
+	return &JsonProvider{source: source}
+}
+
+// Source returns the configured file path, glob pattern or directory.
+func (j *JsonProvider) Source() string {
+	return j.source
 }
 
 func (j *JsonProvider) GetJsonData() (map[string]interface{}, error) {
-	data, err := os.ReadFile("./data.json")
+	snapshot, err := j.GetSnapshot()
 	if err != nil {
 		return nil, err
 	}
 
-	var res map[string]interface{}
-	if err = json.Unmarshal(data, &res); err != nil {
+	return snapshot.Decode()
+}
+
+// GetSnapshot reads the source once and returns the data with its content hash.
+func (j *JsonProvider) GetSnapshot() (*Snapshot, error) {
+	data, err := j.GetRawJson()
+	if err != nil {
 		return nil, err
 	}
 
-	return res, nil
+	return NewSnapshot(data), nil
 }
 
 func (j *JsonProvider) GetRawJson() ([]byte, error) {
-	data, err := os.ReadFile("./data.json")
+	files, multi, err := j.files()
 	if err != nil {
 		return nil, err
 	}
 
-	return data, nil
+	if !multi {
+		return os.ReadFile(files[0])
+	}
+
+	return combineFiles(files)
+}
+
+// files resolves the source to the list of files to read.
+// The second return value reports whether the files are combined into one document.
+func (j *JsonProvider) files() ([]string, bool, error) {
+	if j.isDir() {
+		files, err := filepath.Glob(filepath.Join(j.source, "*.json"))
+		if err != nil {
+			return nil, false, err
+		}
+
+		return nonEmpty(j.source, files)
+	}
+
+	if isGlob(j.source) {
+		files, err := filepath.Glob(j.source)
+		if err != nil {
+			return nil, false, err
+		}
+
+		return nonEmpty(j.source, files)
+	}
+
+	return []string{j.source}, false, nil
+}
+
+// WatchDirs returns the directories that contain the data files.
+func (j *JsonProvider) WatchDirs() ([]string, error) {
+	if j.isDir() {
+		return []string{j.source}, nil
+	}
+
+	dir := filepath.Dir(j.source)
+	if isGlob(dir) {
+		return filepath.Glob(dir)
+	}
+
+	return []string{dir}, nil
+}
+
+// Matches reports whether a changed path belongs to the data source.
+func (j *JsonProvider) Matches(path string) bool {
+	path = filepath.Clean(path)
+
+	if j.isDir() {
+		return filepath.Dir(path) == filepath.Clean(j.source) && filepath.Ext(path) == ".json"
+	}
+
+	if isGlob(j.source) {
+		ok, err := filepath.Match(filepath.Clean(j.source), path)
+
+		return err == nil && ok
+	}
+
+	return path == filepath.Clean(j.source)
+}
+
+func (j *JsonProvider) isDir() bool {
+	info, err := os.Stat(j.source)
+
+	return err == nil && info.IsDir()
+}
+
+func nonEmpty(source string, files []string) ([]string, bool, error) {
+	if len(files) == 0 {
+		return nil, false, fmt.Errorf("%w: %s", ErrNoDataFiles, source)
+	}
+
+	sort.Strings(files)
+
+	return files, true, nil
+}
+
+// combineFiles builds a single JSON object with one key per file.
+func combineFiles(files []string) ([]byte, error) {
+	combined := make(map[string]json.RawMessage, len(files))
+	origin := make(map[string]string, len(files))
+
+	for _, file := range files {
+		key := fieldName(file)
+		if prev, ok := origin[key]; ok {
+			return nil, fmt.Errorf("%w: %s and %s", ErrDuplicateDataFile, prev, file)
+		}
+
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		if !json.Valid(data) {
+			return nil, fmt.Errorf("invalid json in %s", file)
+		}
+
+		combined[key] = data
+		origin[key] = file
+	}
+
+	return json.Marshal(combined)
+}
+
+// fieldName returns the root field name for a data file,
+// e.g. "fixtures/users.json" -> "users".
+func fieldName(file string) string {
+	base := filepath.Base(file)
+
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+func isGlob(source string) bool {
+	return strings.ContainsAny(source, "*?[")
 }
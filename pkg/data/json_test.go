@@ -0,0 +1,110 @@
+package data
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+
+	return path
+}
+
+// TestSingleFile verifies that a single file is exposed as is.
+func TestSingleFile(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "data.json", `{"user": {"name": "John"}}`)
+
+	data, err := NewJSONProviderFromSource(path).GetJsonData()
+	assert.NoError(t, err, "Reading a single file should not error")
+	assert.Equal(t, map[string]interface{}{"user": map[string]interface{}{"name": "John"}}, data)
+}
+
+// TestDirectory verifies that every file in a directory becomes a root field.
+func TestDirectory(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "users.json", `[{"id": 1}]`)
+	writeFile(t, dir, "orders.json", `[{"id": 2}]`)
+	writeFile(t, dir, "products.json", `{"total": 3}`)
+	writeFile(t, dir, "notes.txt", `not json`)
+
+	data, err := NewJSONProviderFromSource(dir).GetJsonData()
+	assert.NoError(t, err, "Reading a directory should not error")
+	assert.Equal(t, map[string]interface{}{
+		"users":    []interface{}{map[string]interface{}{"id": json.Number("1")}},
+		"orders":   []interface{}{map[string]interface{}{"id": json.Number("2")}},
+		"products": map[string]interface{}{"total": json.Number("3")},
+	}, data)
+}
+
+// TestGlob verifies that only files matching the pattern are combined.
+func TestGlob(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "users.json", `[]`)
+	writeFile(t, dir, "orders.json", `[]`)
+
+	data, err := NewJSONProviderFromSource(filepath.Join(dir, "u*.json")).GetJsonData()
+	assert.NoError(t, err, "Reading a glob should not error")
+	assert.Equal(t, map[string]interface{}{"users": []interface{}{}}, data)
+}
+
+// TestNoMatches verifies that an empty directory or glob is reported.
+func TestNoMatches(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := NewJSONProviderFromSource(dir).GetRawJson()
+	assert.ErrorIs(t, err, ErrNoDataFiles)
+
+	_, err = NewJSONProviderFromSource(filepath.Join(dir, "*.json")).GetRawJson()
+	assert.ErrorIs(t, err, ErrNoDataFiles)
+}
+
+// TestInvalidFileInDirectory verifies that a broken file fails the whole read.
+func TestInvalidFileInDirectory(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "users.json", `[{"id": 1}]`)
+	writeFile(t, dir, "orders.json", `[{"id":`)
+
+	_, err := NewJSONProviderFromSource(dir).GetRawJson()
+	assert.Error(t, err, "Invalid json should error")
+}
+
+// TestMatches verifies that only files of the source are reported as changes.
+func TestMatches(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "data.json")
+
+	assert.True(t, NewJSONProviderFromSource(file).Matches(file))
+	assert.False(t, NewJSONProviderFromSource(file).Matches(filepath.Join(dir, ".data.json.swp")))
+
+	assert.True(t, NewJSONProviderFromSource(dir).Matches(filepath.Join(dir, "users.json")))
+	assert.False(t, NewJSONProviderFromSource(dir).Matches(filepath.Join(dir, "notes.txt")))
+
+	glob := filepath.Join(dir, "u*.json")
+	assert.True(t, NewJSONProviderFromSource(glob).Matches(filepath.Join(dir, "users.json")))
+	assert.False(t, NewJSONProviderFromSource(glob).Matches(filepath.Join(dir, "orders.json")))
+
+	dirs, err := NewJSONProviderFromSource(glob).WatchDirs()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{dir}, dirs)
+}
+
+// TestDecodeNumbers verifies that numbers are decoded as json.Number and trailing data is rejected.
+func TestDecodeNumbers(t *testing.T) {
+	res, err := NewSnapshot([]byte(`{"count": 3, "price": 2.5}`)).Decode()
+	assert.NoError(t, err, "Decoding should not error")
+	assert.Equal(t, map[string]interface{}{"count": json.Number("3"), "price": json.Number("2.5")}, res)
+
+	_, err = NewSnapshot([]byte(`{"count": 3} {}`)).Decode()
+	assert.ErrorIs(t, err, ErrTrailingData)
+}
@@ -0,0 +1,43 @@
+package data
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+)
+
+// Snapshot is a single read of the data source together with its content hash.
+type Snapshot struct {
+	Raw     []byte
+	Version string
+}
+
+// NewSnapshot creates a snapshot versioned by the sha256 of the raw data.
+func NewSnapshot(raw []byte) *Snapshot {
+	sum := sha256.Sum256(raw)
+
+	return &Snapshot{
+		Raw:     raw,
+		Version: hex.EncodeToString(sum[:]),
+	}
+}
+
+// Decode parses the raw data. Numbers are decoded as json.Number
+// to keep integers apart from floats.
+func (s *Snapshot) Decode() (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(s.Raw))
+	decoder.UseNumber()
+
+	var res map[string]interface{}
+	if err := decoder.Decode(&res); err != nil {
+		return nil, err
+	}
+
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, ErrTrailingData
+	}
+
+	return res, nil
+}
@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/location"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// Error codes set in the "extensions.code" of errors when enabled with SetErrorCodes.
+const (
+	CodeParseFailed      = "GRAPHQL_PARSE_FAILED"
+	CodeValidationFailed = "GRAPHQL_VALIDATION_FAILED"
+	CodeBadUserInput     = "BAD_USER_INPUT"
+	CodeInternalError    = "INTERNAL_SERVER_ERROR"
+)
+
+// response is the GraphQL response body.
+// Data is left out when the request failed before execution started, see MarshalJSON.
+type response struct {
+	Data       interface{}                `json:"data,omitempty"`
+	Errors     []gqlerrors.FormattedError `json:"errors,omitempty"`
+	Extensions map[string]interface{}     `json:"extensions,omitempty"`
+
+	executed  bool
+	status    int
+	errorCode string
+}
+
+// MarshalJSON encodes the response. Once execution started data is always present,
+// null when an error in a non-null root field nulled it.
+func (r *response) MarshalJSON() ([]byte, error) {
+	type body response
+	if !r.executed {
+		return json.Marshal((*body)(r))
+	}
+
+	return json.Marshal(struct {
+		Data interface{} `json:"data"`
+		*body
+	}{Data: r.Data, body: (*body)(r)})
+}
+
+// execute runs the request and returns the response with its HTTP status:
+// 400 when the document can't be parsed or validated, the operation can't be selected
+// or its variables can't be coerced, 200 when execution started, even if some fields failed.
+func execute(ctx context.Context, schema graphql.Schema, req *Request) *response {
+	src := source.NewSource(&source.Source{
+		Body: []byte(req.Query),
+		Name: "GraphQL request",
+	})
+
+	doc, err := parser.Parse(parser.ParseParams{Source: src})
+	if err != nil {
+		return &response{
+			Errors:    gqlerrors.FormatErrors(err),
+			status:    http.StatusBadRequest,
+			errorCode: CodeParseFailed,
+		}
+	}
+
+	validation := graphql.ValidateDocument(&schema, doc, nil)
+	if !validation.IsValid {
+		return &response{
+			Errors:    validation.Errors,
+			status:    http.StatusBadRequest,
+			errorCode: CodeValidationFailed,
+		}
+	}
+
+	operation, err := selectOperation(schema, doc, req.OperationName)
+	if err != nil {
+		return &response{
+			Errors:    gqlerrors.FormatErrors(err),
+			status:    http.StatusBadRequest,
+			errorCode: CodeBadUserInput,
+		}
+	}
+
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       ctx,
+	})
+
+	// graphql-go coerces the variables before executing the operation and returns no data when that fails.
+	if result.Data == nil && variablesRejected(src, operation, result.Errors) {
+		return &response{
+			Errors:    result.Errors,
+			status:    http.StatusBadRequest,
+			errorCode: CodeBadUserInput,
+		}
+	}
+
+	return &response{
+		Data:       result.Data,
+		Errors:     result.Errors,
+		Extensions: result.Extensions,
+		executed:   true,
+		status:     http.StatusOK,
+		errorCode:  CodeInternalError,
+	}
+}
+
+// selectOperation returns the operation of the document to execute, as graphql.Execute selects it:
+// the operation named operationName, or the only operation of the document when no name is given.
+func selectOperation(schema graphql.Schema, doc *ast.Document, operationName string) (*ast.OperationDefinition, error) {
+	var operation *ast.OperationDefinition
+	for _, def := range doc.Definitions {
+		op, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+
+		if operationName == "" {
+			if operation != nil {
+				return nil, errors.New("Must provide operation name if query contains multiple operations.")
+			}
+
+			operation = op
+		} else if op.Name != nil && op.Name.Value == operationName {
+			operation = op
+		}
+	}
+
+	if operation == nil {
+		if operationName != "" {
+			return nil, fmt.Errorf("Unknown operation named %q.", operationName)
+		}
+
+		return nil, errors.New("Must provide an operation.")
+	}
+
+	if operation.Operation == ast.OperationTypeMutation && schema.MutationType() == nil ||
+		operation.Operation == ast.OperationTypeSubscription && schema.SubscriptionType() == nil {
+		return nil, fmt.Errorf("Schema is not configured for %ss.", operation.Operation)
+	}
+
+	return operation, nil
+}
+
+// variablesRejected reports whether the errors are those of variables that couldn't be coerced
+// to the types of the operation. graphql-go doesn't export its coercion, its errors are told apart
+// from field errors by their location, the definition of the variable.
+func variablesRejected(src *source.Source, operation *ast.OperationDefinition, errs []gqlerrors.FormattedError) bool {
+	if len(errs) == 0 || len(operation.VariableDefinitions) == 0 {
+		return false
+	}
+
+	definitions := make(map[location.SourceLocation]bool, len(operation.VariableDefinitions))
+	for _, def := range operation.VariableDefinitions {
+		if def.Loc != nil {
+			definitions[location.GetLocation(src, def.Loc.Start)] = true
+		}
+	}
+
+	for _, err := range errs {
+		if len(err.Locations) != 1 || !definitions[err.Locations[0]] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// withCode marks the errors with the code unless they already carry one,
+// e.g. from a resolver error implementing gqlerrors.ExtendedError.
+func withCode(errs []gqlerrors.FormattedError, code string) []gqlerrors.FormattedError {
+	for i, err := range errs {
+		if _, ok := err.Extensions["code"]; ok {
+			continue
+		}
+
+		extensions := make(map[string]interface{}, len(err.Extensions)+1)
+		for k, v := range err.Extensions {
+			extensions[k] = v
+		}
+		extensions["code"] = code
+
+		errs[i].Extensions = extensions
+	}
+
+	return errs
+}
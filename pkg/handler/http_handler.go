@@ -2,19 +2,27 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
 
 	"github.com/graphql-go/graphql"
+	"github.com/niklod/json-to-graphql-go/pkg/snapshot"
 )
 
 type SchemaBuilder interface {
 	BuildSchema(jsonData map[string]interface{}) (*graphql.Schema, error)
 }
 
+// ExtensionsFunc returns entries added to the "extensions" of every response.
+type ExtensionsFunc func(s *snapshot.Snapshot) map[string]interface{}
+
 type GraphQLHandler struct {
 	schemaBuilder SchemaBuilder
-	schema        *graphql.Schema
+	snapshots     snapshot.Store
+	extensions    ExtensionsFunc
+	errorCodes    bool
+	playground    bool
 }
 
 func NewGraphQLHandler(
@@ -22,44 +30,112 @@ func NewGraphQLHandler(
 ) *GraphQLHandler {
 	return &GraphQLHandler{
 		schemaBuilder: schemaBuilder,
+		playground:    true,
 	}
 }
 
-func (h *GraphQLHandler) UpdateSchema(schema *graphql.Schema) {
-	h.schema = schema
+// UpdateSnapshot atomically replaces the schema and data used for new requests.
+// Requests in flight finish with the snapshot they started with.
+func (h *GraphQLHandler) UpdateSnapshot(s *snapshot.Snapshot) {
+	h.snapshots.Store(s)
+}
+
+// SetExtensionsFunc sets the function providing response extensions.
+func (h *GraphQLHandler) SetExtensionsFunc(fn ExtensionsFunc) {
+	h.extensions = fn
+}
+
+// SetErrorCodes enables "extensions.code" on errors, e.g. GRAPHQL_VALIDATION_FAILED.
+func (h *GraphQLHandler) SetErrorCodes(enabled bool) {
+	h.errorCodes = enabled
+}
+
+// SetPlayground enables or disables the playground page served to browsers.
+// It is enabled by default.
+func (h *GraphQLHandler) SetPlayground(enabled bool) {
+	h.playground = enabled
 }
 
+// Snapshot returns the snapshot used for new requests.
+func (h *GraphQLHandler) Snapshot() *snapshot.Snapshot {
+	return h.snapshots.Load()
+}
+
+// ServeHTTP implements GraphQL over HTTP: queries are accepted as GET query parameters,
+// as a JSON POST body or as an application/graphql POST body, and the response is encoded
+// as application/json or application/graphql-response+json depending on the Accept header.
+// Errors are returned in the "errors" of the response body next to any partial data.
+// Browsers opening the endpoint get the playground page unless it is disabled.
 func (h *GraphQLHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	var params struct {
-		Query string `json:"query"`
+	if h.playground && wantsPlayground(r) {
+		servePlayground(w)
+
+		return
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
-		http.Error(w, "failed to decode request body", http.StatusBadRequest)
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, errMethodNotAllowed.Error(), http.StatusMethodNotAllowed)
 
 		return
 	}
 
-	if h.schema == nil {
-		http.Error(w, "schema is not configured", http.StatusInternalServerError)
+	contentType, ok := negotiateContentType(r.Header.Get("Accept"), contentTypeJSON, contentTypeGraphQLResponse)
+	if !ok {
+		http.Error(w, errNotAcceptable.Error(), http.StatusNotAcceptable)
 
 		return
 	}
 
-	result := graphql.Do(graphql.Params{
-		Schema:        *h.schema,
-		RequestString: params.Query,
-	})
+	params, err := parseRequest(r)
+	if err != nil {
+		status := http.StatusBadRequest
+
+		var reqErr *requestError
+		if errors.As(err, &reqErr) {
+			status = reqErr.status
+		}
+
+		http.Error(w, err.Error(), status)
 
-	if len(result.Errors) > 0 {
-		log.Printf("failed to execute graphql operation, errors: %+v", result.Errors)
-		http.Error(w, "failed to execute graphql operation", http.StatusInternalServerError)
+		return
+	}
+
+	current := h.snapshots.Load()
+	if current == nil || current.Schema == nil {
+		http.Error(w, "schema is not configured", http.StatusInternalServerError)
 
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(result); err != nil {
+	resp := execute(snapshot.NewContext(r.Context(), current), *current.Schema, params)
+
+	if len(resp.Errors) > 0 {
+		log.Printf("graphql operation finished with errors: %+v", resp.Errors)
+
+		if h.errorCodes {
+			resp.Errors = withCode(resp.Errors, resp.errorCode)
+		}
+	}
+
+	if h.extensions != nil {
+		for k, v := range h.extensions(current) {
+			if resp.Extensions == nil {
+				resp.Extensions = make(map[string]interface{})
+			}
+
+			resp.Extensions[k] = v
+		}
+	}
+
+	body, err := json.Marshal(resp)
+	if err != nil {
 		http.Error(w, "failed to encode response", http.StatusInternalServerError)
+
+		return
 	}
+
+	w.Header().Set("Content-Type", contentType+"; charset=utf-8")
+	w.WriteHeader(resp.status)
+	_, _ = w.Write(body)
 }
@@ -0,0 +1,326 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/niklod/json-to-graphql-go/pkg/snapshot"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestHandler(t *testing.T) *GraphQLHandler {
+	t.Helper()
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "RootQuery",
+			Fields: graphql.Fields{
+				"fail": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return nil, errors.New("resolver failed")
+					},
+				},
+				"failRequired": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.String),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return nil, errors.New("required resolver failed")
+					},
+				},
+				"hello": &graphql.Field{
+					Type: graphql.String,
+					Args: graphql.FieldConfigArgument{
+						"name": &graphql.ArgumentConfig{Type: graphql.String},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						if name, ok := p.Args["name"].(string); ok {
+							return "hello " + name, nil
+						}
+
+						return "hello", nil
+					},
+				},
+			},
+		}),
+	})
+	assert.NoError(t, err, "Schema creation should not error")
+
+	h := NewGraphQLHandler(nil)
+	h.UpdateSnapshot(&snapshot.Snapshot{Schema: &schema})
+
+	return h
+}
+
+func serve(h http.Handler, r *http.Request) (*httptest.ResponseRecorder, map[string]interface{}) {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, r)
+
+	var res map[string]interface{}
+	_ = json.Unmarshal(rec.Body.Bytes(), &res)
+
+	return rec, res
+}
+
+func hello(res map[string]interface{}) interface{} {
+	data, _ := res["data"].(map[string]interface{})
+
+	return data["hello"]
+}
+
+// TestPostJSON verifies that variables and operationName are used.
+func TestPostJSON(t *testing.T) {
+	body := `{
+        "query": "query A { hello } query B($name: String) { hello(name: $name) }",
+        "operationName": "B",
+        "variables": {"name": "John"}
+    }`
+	r := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	rec, res := serve(newTestHandler(t), r)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "hello John", hello(res))
+}
+
+// TestGet verifies that the request can be sent as query parameters.
+func TestGet(t *testing.T) {
+	params := url.Values{
+		"query":     {"query($name: String) { hello(name: $name) }"},
+		"variables": {`{"name": "Jane"}`},
+	}
+	r := httptest.NewRequest(http.MethodGet, "/graphql?"+params.Encode(), nil)
+
+	rec, res := serve(newTestHandler(t), r)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "hello Jane", hello(res))
+}
+
+// TestGetInvalidVariables verifies that malformed variables are a bad request.
+func TestGetInvalidVariables(t *testing.T) {
+	params := url.Values{"query": {"{ hello }"}, "variables": {"{"}}
+	r := httptest.NewRequest(http.MethodGet, "/graphql?"+params.Encode(), nil)
+
+	rec, _ := serve(newTestHandler(t), r)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+// TestPostGraphQL verifies that application/graphql bodies are accepted.
+func TestPostGraphQL(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{ hello(name: "Bob") }`))
+	r.Header.Set("Content-Type", "application/graphql")
+
+	rec, res := serve(newTestHandler(t), r)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "hello Bob", hello(res))
+}
+
+// TestContentNegotiation verifies the response media type selection.
+func TestContentNegotiation(t *testing.T) {
+	tests := []struct {
+		name        string
+		accept      string
+		status      int
+		contentType string
+	}{
+		{name: "No accept header", accept: "", status: http.StatusOK, contentType: "application/json; charset=utf-8"},
+		{name: "Wildcard", accept: "*/*", status: http.StatusOK, contentType: "application/json; charset=utf-8"},
+		{
+			name:        "GraphQL response",
+			accept:      "application/graphql-response+json, application/json;q=0.9",
+			status:      http.StatusOK,
+			contentType: "application/graphql-response+json; charset=utf-8",
+		},
+		{
+			name:        "Preferred by quality",
+			accept:      "application/graphql-response+json;q=0.5, application/json",
+			status:      http.StatusOK,
+			contentType: "application/json; charset=utf-8",
+		},
+		{name: "Unsupported", accept: "application/xml", status: http.StatusNotAcceptable},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query": "{ hello }"}`))
+			r.Header.Set("Content-Type", "application/json")
+			r.Header.Set("Accept", test.accept)
+
+			rec, _ := serve(newTestHandler(t), r)
+			assert.Equal(t, test.status, rec.Code)
+
+			if test.contentType != "" {
+				assert.Equal(t, test.contentType, rec.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
+
+// TestRequestErrors verifies the status codes of malformed requests.
+func TestRequestErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		contentType string
+		body        string
+		status      int
+	}{
+		{name: "Wrong method", method: http.MethodPut, body: `{"query": "{ hello }"}`, status: http.StatusMethodNotAllowed},
+		{name: "Unsupported content type", method: http.MethodPost, contentType: "text/plain", body: `{ hello }`, status: http.StatusUnsupportedMediaType},
+		{name: "Invalid json", method: http.MethodPost, contentType: "application/json", body: `{`, status: http.StatusBadRequest},
+		{name: "Missing query", method: http.MethodPost, contentType: "application/json", body: `{}`, status: http.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest(test.method, "/graphql", strings.NewReader(test.body))
+			if test.contentType != "" {
+				r.Header.Set("Content-Type", test.contentType)
+			}
+
+			rec, _ := serve(newTestHandler(t), r)
+			assert.Equal(t, test.status, rec.Code)
+
+			if test.status == http.StatusMethodNotAllowed {
+				assert.Equal(t, "GET, POST", rec.Header().Get("Allow"))
+			}
+		})
+	}
+}
+
+func postQuery(query string) *http.Request {
+	body, _ := json.Marshal(map[string]string{"query": query})
+	r := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body)))
+	r.Header.Set("Content-Type", "application/json")
+
+	return r
+}
+
+func firstError(res map[string]interface{}) map[string]interface{} {
+	errs, _ := res["errors"].([]interface{})
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs[0].(map[string]interface{})
+}
+
+// TestExecutionErrorKeepsPartialData verifies that field errors are returned next to the data.
+func TestExecutionErrorKeepsPartialData(t *testing.T) {
+	rec, res := serve(newTestHandler(t), postQuery(`{ hello fail }`))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "hello", hello(res), "Partial data should be returned")
+
+	err := firstError(res)
+	assert.Equal(t, "resolver failed", err["message"])
+	assert.Equal(t, []interface{}{"fail"}, err["path"])
+	assert.Nil(t, err["extensions"], "Codes should be disabled by default")
+}
+
+// TestNullDataIsKept verifies that data nulled by a non-null root field error is returned as null with 200.
+func TestNullDataIsKept(t *testing.T) {
+	rec, res := serve(newTestHandler(t), postQuery(`{ hello failRequired }`))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	data, hasData := res["data"]
+	assert.True(t, hasData, "Data should be present once execution started")
+	assert.Nil(t, data)
+	assert.Equal(t, "required resolver failed", firstError(res)["message"])
+}
+
+// TestNullDataWithVariablesIsKept verifies that data nulled during execution is not taken
+// for rejected variables, which also leave no data.
+func TestNullDataWithVariablesIsKept(t *testing.T) {
+	body := `{"query": "query($name: String!) { hello(name: $name) failRequired }", "variables": {"name": "bob"}}`
+	rec, res := serve(newTestHandler(t), httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	data, hasData := res["data"]
+	assert.True(t, hasData, "Data should be present once execution started")
+	assert.Nil(t, data)
+	assert.Equal(t, "required resolver failed", firstError(res)["message"])
+}
+
+// TestRequestErrorsHaveNoData verifies that documents which can't be executed return 400.
+func TestRequestErrorsHaveNoData(t *testing.T) {
+	tests := []struct {
+		name    string
+		request *http.Request
+		code    string
+	}{
+		{name: "Parse error", request: postQuery(`{ hello `), code: CodeParseFailed},
+		{name: "Validation error", request: postQuery(`{ unknown }`), code: CodeValidationFailed},
+		{name: "Unknown operation", request: func() *http.Request {
+			body := `{"query": "query A { hello }", "operationName": "B"}`
+
+			return httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+		}(), code: CodeBadUserInput},
+		{name: "Ambiguous operation", request: postQuery(`query A { hello } query B { hello }`), code: CodeBadUserInput},
+		{name: "Missing variable", request: postQuery(`query($name: String!) { hello(name: $name) }`), code: CodeBadUserInput},
+		{name: "Null for a non-null variable", request: func() *http.Request {
+			body := `{"query": "query($name: String!) { hello(name: $name) }", "variables": {"name": null}}`
+
+			return httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+		}(), code: CodeBadUserInput},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			h := newTestHandler(t)
+			h.SetErrorCodes(true)
+
+			rec, res := serve(h, test.request)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Equal(t, "application/json; charset=utf-8", rec.Header().Get("Content-Type"))
+
+			_, hasData := res["data"]
+			assert.False(t, hasData, "Data should be left out")
+
+			err := firstError(res)
+			assert.NotEmpty(t, err["message"])
+			assert.Equal(t, test.code, err["extensions"].(map[string]interface{})["code"])
+		})
+	}
+}
+
+// TestExecutionErrorCode verifies that execution errors are marked when codes are enabled.
+func TestExecutionErrorCode(t *testing.T) {
+	h := newTestHandler(t)
+	h.SetErrorCodes(true)
+
+	_, res := serve(h, postQuery(`{ fail }`))
+	assert.Equal(t, CodeInternalError, firstError(res)["extensions"].(map[string]interface{})["code"])
+}
+
+// TestPlayground verifies that browsers get the playground page only when it is enabled.
+func TestPlayground(t *testing.T) {
+	browser := func() *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/graphql", nil)
+		r.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+
+		return r
+	}
+
+	h := newTestHandler(t)
+
+	rec, _ := serve(h, browser())
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/html; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.NotContains(t, rec.Body.String(), "https://", "Page should not load anything from the network")
+
+	r := browser()
+	r.URL.RawQuery = url.Values{"query": {"{ hello }"}}.Encode()
+	rec, res := serve(h, r)
+	assert.Equal(t, "hello", hello(res), "Queries from browsers should be executed")
+
+	h.SetPlayground(false)
+
+	rec, _ = serve(h, browser())
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "Disabled playground should not be served")
+}
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+const contentTypeHTML = "text/html"
+
+// playgroundPage is a self-contained query editor and schema explorer, with syntax highlighting,
+// completion of fields and arguments from the introspected schema and prettifying.
+// It loads nothing from the network except the endpoint it is served from,
+// so it works offline and always reflects the live schema.
+//
+//go:embed playground.html
+var playgroundPage []byte
+
+// wantsPlayground reports whether the request comes from a browser opening the endpoint.
+func wantsPlayground(r *http.Request) bool {
+	if r.Method != http.MethodGet || r.URL.Query().Has("query") {
+		return false
+	}
+
+	contentType, ok := negotiateContentType(r.Header.Get("Accept"), contentTypeJSON, contentTypeGraphQLResponse, contentTypeHTML)
+
+	return ok && contentType == contentTypeHTML
+}
+
+func servePlayground(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", contentTypeHTML+"; charset=utf-8")
+	_, _ = w.Write(playgroundPage)
+}
@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	contentTypeJSON            = "application/json"
+	contentTypeGraphQL         = "application/graphql"
+	contentTypeGraphQLResponse = "application/graphql-response+json"
+)
+
+var (
+	errMethodNotAllowed     = errors.New("method not allowed, use GET or POST")
+	errUnsupportedMediaType = errors.New("unsupported content type, use application/json or application/graphql")
+	errNotAcceptable        = errors.New("not acceptable, use application/json or application/graphql-response+json")
+	errMissingQuery         = errors.New("query is required")
+)
+
+// Request is a GraphQL-over-HTTP request.
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+	Extensions    map[string]interface{} `json:"extensions"`
+}
+
+// requestError is an error with the HTTP status it must be answered with.
+type requestError struct {
+	status int
+	err    error
+}
+
+func (e *requestError) Error() string {
+	return e.err.Error()
+}
+
+func (e *requestError) Unwrap() error {
+	return e.err
+}
+
+func badRequest(err error) error {
+	return &requestError{status: http.StatusBadRequest, err: err}
+}
+
+// parseRequest reads the GraphQL request from the query string of a GET request
+// or from the body of a POST request.
+func parseRequest(r *http.Request) (*Request, error) {
+	var req *Request
+	var err error
+
+	switch r.Method {
+	case http.MethodGet:
+		req, err = parseQueryString(r.URL.Query())
+	case http.MethodPost:
+		req, err = parseBody(r)
+	default:
+		return nil, &requestError{status: http.StatusMethodNotAllowed, err: errMethodNotAllowed}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(req.Query) == "" {
+		return nil, badRequest(errMissingQuery)
+	}
+
+	return req, nil
+}
+
+func parseQueryString(values url.Values) (*Request, error) {
+	req := &Request{
+		Query:         values.Get("query"),
+		OperationName: values.Get("operationName"),
+	}
+
+	if err := decodeParam(values, "variables", &req.Variables); err != nil {
+		return nil, err
+	}
+
+	if err := decodeParam(values, "extensions", &req.Extensions); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func decodeParam(values url.Values, name string, dst *map[string]interface{}) error {
+	raw := values.Get(name)
+	if raw == "" {
+		return nil
+	}
+
+	if err := json.Unmarshal([]byte(raw), dst); err != nil {
+		return badRequest(fmt.Errorf("failed to decode %s: %w", name, err))
+	}
+
+	return nil
+}
+
+func parseBody(r *http.Request) (*Request, error) {
+	// Clients that don't send a content type are assumed to send JSON.
+	mediaType := contentTypeJSON
+	if header := r.Header.Get("Content-Type"); header != "" {
+		parsed, _, err := mime.ParseMediaType(header)
+		if err != nil {
+			return nil, &requestError{status: http.StatusUnsupportedMediaType, err: errUnsupportedMediaType}
+		}
+
+		mediaType = parsed
+	}
+
+	switch mediaType {
+	case contentTypeJSON:
+		var req Request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return nil, badRequest(fmt.Errorf("failed to decode request body: %w", err))
+		}
+
+		return &req, nil
+	case contentTypeGraphQL:
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, badRequest(fmt.Errorf("failed to read request body: %w", err))
+		}
+
+		// The query string may carry the remaining parameters.
+		req, err := parseQueryString(r.URL.Query())
+		if err != nil {
+			return nil, err
+		}
+
+		req.Query = string(body)
+
+		return req, nil
+	default:
+		return nil, &requestError{status: http.StatusUnsupportedMediaType, err: errUnsupportedMediaType}
+	}
+}
+
+// negotiateContentType picks the response media type from the Accept header.
+// A missing header means application/json for compatibility with older clients.
+func negotiateContentType(accept string, offers ...string) (string, bool) {
+	if strings.TrimSpace(accept) == "" {
+		return offers[0], true
+	}
+
+	type mediaRange struct {
+		mediaType string
+		q         float64
+	}
+
+	var ranges []mediaRange
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+
+		if q > 0 {
+			ranges = append(ranges, mediaRange{mediaType: mediaType, q: q})
+		}
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].q > ranges[j].q })
+
+	for _, rng := range ranges {
+		for _, offer := range offers {
+			if mediaTypeMatches(rng.mediaType, offer) {
+				return offer, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+func mediaTypeMatches(pattern, mediaType string) bool {
+	if pattern == "*/*" || pattern == mediaType {
+		return true
+	}
+
+	prefix, ok := strings.CutSuffix(pattern, "/*")
+
+	return ok && strings.HasPrefix(mediaType, prefix+"/")
+}
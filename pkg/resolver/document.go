@@ -0,0 +1,59 @@
+package resolver
+
+import (
+	"sync"
+
+	"github.com/tidwall/gjson"
+)
+
+// document is the data of a snapshot parsed once: the data is converted to a string a single time
+// and the root values are indexed by key, so resolving a root field doesn't scan the whole data.
+type document struct {
+	data []byte
+	root map[string]gjson.Result
+
+	mu     sync.Mutex
+	arrays map[string][]gjson.Result // root key -> elements of the array, see elements
+}
+
+func newDocument(data []byte) *document {
+	root := make(map[string]gjson.Result)
+	gjson.Parse(string(data)).ForEach(func(key, value gjson.Result) bool {
+		// gjson returns the first of duplicate keys.
+		if _, exists := root[key.Str]; !exists {
+			root[key.Str] = value
+		}
+
+		return true
+	})
+
+	return &document{
+		data:   data,
+		root:   root,
+		arrays: make(map[string][]gjson.Result),
+	}
+}
+
+// of reports whether the document was parsed from the data, comparing the slices, not their contents.
+func (d *document) of(data []byte) bool {
+	if len(d.data) != len(data) {
+		return false
+	}
+
+	return len(data) == 0 || &d.data[0] == &data[0]
+}
+
+// elements returns the elements of the root array of the key, split once per document.
+// The returned slice is shared and must not be modified.
+func (d *document) elements(key string) []gjson.Result {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	elements, ok := d.arrays[key]
+	if !ok && d.root[key].IsArray() {
+		elements = d.root[key].Array()
+		d.arrays[key] = elements
+	}
+
+	return elements
+}
@@ -0,0 +1,179 @@
+package resolver
+
+import (
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// filter returns the elements matching the "where" argument of a list field.
+func filter(elements []gjson.Result, where map[string]interface{}) []gjson.Result {
+	res := make([]gjson.Result, 0, len(elements))
+	for _, e := range elements {
+		if matches(e, where) {
+			res = append(res, e)
+		}
+	}
+
+	return res
+}
+
+// matches reports whether the object satisfies every condition of the where input.
+// A condition on a nested object is a where input itself, a condition on a scalar
+// is a map of operators, e.g. {"price": {"gt": 100}, "stat": {"name": {"eq": "fire"}}}.
+func matches(obj gjson.Result, where map[string]interface{}) bool {
+	for key, cond := range where {
+		switch key {
+		case "AND":
+			for _, sub := range conditions(cond) {
+				if !matches(obj, sub) {
+					return false
+				}
+			}
+		case "OR":
+			subs := conditions(cond)
+			if len(subs) == 0 {
+				continue
+			}
+
+			matched := false
+			for _, sub := range subs {
+				if matches(obj, sub) {
+					matched = true
+
+					break
+				}
+			}
+
+			if !matched {
+				return false
+			}
+		default:
+			ops, ok := cond.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			value := get(obj, key)
+			if value.IsObject() {
+				if !matches(value, ops) {
+					return false
+				}
+
+				continue
+			}
+
+			if !matchesOperators(value, ops) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func conditions(v interface{}) []map[string]interface{} {
+	list, _ := v.([]interface{})
+
+	res := make([]map[string]interface{}, 0, len(list))
+	for _, item := range list {
+		if m, ok := item.(map[string]interface{}); ok {
+			res = append(res, m)
+		}
+	}
+
+	return res
+}
+
+// matchesOperators applies scalar filter operators to the value.
+func matchesOperators(value gjson.Result, ops map[string]interface{}) bool {
+	for op, want := range ops {
+		var ok bool
+
+		switch op {
+		case "eq":
+			ok = equal(value, want)
+		case "neq":
+			ok = !equal(value, want)
+		case "in":
+			list, _ := want.([]interface{})
+			for _, item := range list {
+				if equal(value, item) {
+					ok = true
+
+					break
+				}
+			}
+		case "contains":
+			s, isString := want.(string)
+			ok = isString && value.Type == gjson.String && strings.Contains(value.Str, s)
+		case "gt", "gte", "lt", "lte":
+			ok = compareNumber(value, op, want)
+		}
+
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// equal compares a JSON value with a coerced argument value.
+// Strings also match numbers by their raw text, so ID arguments match numeric ids.
+func equal(value gjson.Result, want interface{}) bool {
+	switch w := want.(type) {
+	case nil:
+		return value.Type == gjson.Null
+	case string:
+		switch value.Type {
+		case gjson.String:
+			return value.Str == w
+		case gjson.Number:
+			return value.Raw == w
+		}
+	case bool:
+		return (value.Type == gjson.True || value.Type == gjson.False) && value.Bool() == w
+	default:
+		if n, ok := toFloat(w); ok {
+			return value.Type == gjson.Number && value.Num == n
+		}
+	}
+
+	return false
+}
+
+func compareNumber(value gjson.Result, op string, want interface{}) bool {
+	n, ok := toFloat(want)
+	if !ok || value.Type != gjson.Number {
+		return false
+	}
+
+	switch op {
+	case "gt":
+		return value.Num > n
+	case "gte":
+		return value.Num >= n
+	case "lt":
+		return value.Num < n
+	case "lte":
+		return value.Num <= n
+	}
+
+	return false
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,191 @@
+package resolver
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+const cursorPrefix = "cursor:"
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// sortElements sorts the elements by the "orderBy" argument of a list field, e.g.
+// [{field: price, direction: DESC}, {field: name}]. Missing and null values are sorted last.
+func sortElements(elements []gjson.Result, orderBy []interface{}) {
+	type sortKey struct {
+		field string
+		desc  bool
+	}
+
+	keys := make([]sortKey, 0, len(orderBy))
+	for _, item := range orderBy {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		field, _ := m["field"].(string)
+		direction, _ := m["direction"].(string)
+		keys = append(keys, sortKey{field: field, desc: direction == "DESC"})
+	}
+
+	if len(keys) == 0 {
+		return
+	}
+
+	sort.SliceStable(elements, func(i, j int) bool {
+		for _, key := range keys {
+			a, b := get(elements[i], key.field), get(elements[j], key.field)
+
+			aNull, bNull := a.Type == gjson.Null, b.Type == gjson.Null
+			if aNull || bNull {
+				if aNull == bNull {
+					continue
+				}
+
+				return bNull
+			}
+
+			c := compareValues(a, b)
+			if c == 0 {
+				continue
+			}
+
+			if key.desc {
+				return c > 0
+			}
+
+			return c < 0
+		}
+
+		return false
+	})
+}
+
+// compareValues orders two non-null JSON values,
+// values of different types are ordered by type.
+func compareValues(a, b gjson.Result) int {
+	if a.Type != b.Type {
+		return int(a.Type) - int(b.Type)
+	}
+
+	switch a.Type {
+	case gjson.Number:
+		switch {
+		case a.Num < b.Num:
+			return -1
+		case a.Num > b.Num:
+			return 1
+		}
+
+		return 0
+	case gjson.String:
+		return strings.Compare(a.Str, b.Str)
+	default:
+		return strings.Compare(a.Raw, b.Raw)
+	}
+}
+
+// limitElements applies the "offset" and "limit" arguments of a list field.
+func limitElements(elements []gjson.Result, args map[string]interface{}) ([]gjson.Result, error) {
+	offset, err := intArg(args, "offset")
+	if err != nil {
+		return nil, err
+	}
+
+	if offset != nil {
+		elements = elements[min(*offset, len(elements)):]
+	}
+
+	limit, err := intArg(args, "limit")
+	if err != nil {
+		return nil, err
+	}
+
+	if limit != nil {
+		elements = elements[:min(*limit, len(elements))]
+	}
+
+	return elements, nil
+}
+
+// connectionWindow returns the range of elements selected by the Relay
+// "after", "before", "first" and "last" arguments.
+func connectionWindow(total int, args map[string]interface{}) (int, int, error) {
+	start, end := 0, total
+
+	if after, ok := args["after"].(string); ok {
+		i, err := decodeCursor(after)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		start = min(max(i+1, 0), total)
+	}
+
+	if before, ok := args["before"].(string); ok {
+		i, err := decodeCursor(before)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		end = max(min(i, total), start)
+	}
+
+	first, err := intArg(args, "first")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if first != nil {
+		end = min(end, start+*first)
+	}
+
+	last, err := intArg(args, "last")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if last != nil {
+		start = max(start, end-*last)
+	}
+
+	return start, end, nil
+}
+
+func encodeCursor(i int) string {
+	return base64.StdEncoding.EncodeToString([]byte(cursorPrefix + strconv.Itoa(i)))
+}
+
+func decodeCursor(cursor string) (int, error) {
+	raw, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidCursor, cursor)
+	}
+
+	i, err := strconv.Atoi(strings.TrimPrefix(string(raw), cursorPrefix))
+	if err != nil || !strings.HasPrefix(string(raw), cursorPrefix) {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidCursor, cursor)
+	}
+
+	return i, nil
+}
+
+func intArg(args map[string]interface{}, name string) (*int, error) {
+	v, ok := args[name].(int)
+	if !ok {
+		return nil, nil
+	}
+
+	if v < 0 {
+		return nil, fmt.Errorf("%s must not be negative", name)
+	}
+
+	return &v, nil
+}
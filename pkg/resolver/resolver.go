@@ -1,70 +1,263 @@
 package resolver
 
 import (
-	"fmt"
-	"strings"
+	"sync/atomic"
 
 	"github.com/graphql-go/graphql"
+	"github.com/niklod/json-to-graphql-go/pkg/snapshot"
 	"github.com/tidwall/gjson"
 )
 
+// JSONResolver resolves fields from the data of the snapshot the request is pinned to.
+// Outside of a pinned request it falls back to the data set by UpdateJsonData.
+// Fields are resolved from their parent value, only root fields are read from the document,
+// which is parsed once per snapshot, see Parse.
 type JSONResolver struct {
-	jsonData []byte
+	current atomic.Pointer[document]
 }
 
 func NewJSONResolver(jsonData []byte) *JSONResolver {
-	return &JSONResolver{jsonData: jsonData}
+	r := &JSONResolver{}
+	r.UpdateJsonData(jsonData)
+
+	return r
 }
 
+// UpdateJsonData parses the data for requests that are not pinned to a snapshot.
+// The data must not be modified afterwards.
 func (r *JSONResolver) UpdateJsonData(jsonData []byte) {
-	r.jsonData = jsonData
+	r.current.Store(newDocument(jsonData))
 }
 
-func (r *JSONResolver) ResolveScalarValue(p graphql.ResolveParams) (interface{}, error) {
-	var key string
-	for i, k := range p.Info.Path.AsArray() {
-		if i > 0 {
-			key += "."
-		}
-		key += fmt.Sprintf("%v", k)
+// Parse returns the parsed data to store on the snapshot of the data, see snapshot.Snapshot.Document.
+// The data set by UpdateJsonData is not parsed again.
+func (r *JSONResolver) Parse(jsonData []byte) interface{} {
+	if doc := r.current.Load(); doc.of(jsonData) {
+		return doc
+	}
+
+	return newDocument(jsonData)
+}
+
+// document returns the document the request must be resolved against.
+// Snapshots built without a document are parsed for the request, unless they hold the current data.
+func (r *JSONResolver) document(p graphql.ResolveParams) *document {
+	current := r.current.Load()
+
+	s, ok := snapshot.FromContext(p.Context)
+	if !ok {
+		return current
+	}
+
+	if doc, ok := s.Document.(*document); ok {
+		return doc
+	}
+
+	if current.of(s.Data) {
+		return current
+	}
+
+	return newDocument(s.Data)
+}
+
+// lookup returns the value of the resolved field.
+// Fields are looked up in their parent value, so list elements that were filtered
+// or reordered still resolve to their own data. Root fields are looked up in the document.
+func (r *JSONResolver) lookup(p graphql.ResolveParams) gjson.Result {
+	if parent, ok := p.Source.(gjson.Result); ok {
+		return get(parent, p.Info.FieldName)
+	}
+
+	return r.document(p).root[p.Info.FieldName]
+}
+
+// Lookup returns the value of the key of an object resolved by the resolver.
+func (r *JSONResolver) Lookup(source interface{}, key string) interface{} {
+	obj, ok := source.(gjson.Result)
+	if !ok {
+		return nil
 	}
 
-	data := gjson.Get(string(r.jsonData), key)
+	return get(obj, key).Value()
+}
 
-	result := data.Value()
+// Offset returns the position of a value resolved by the resolver in its data.
+func (r *JSONResolver) Offset(source interface{}) (int, bool) {
+	value, ok := source.(gjson.Result)
 
-	// fmt.Println("scalar resolved", key, result)
+	// gjson leaves the index at 0 when it doesn't know the position.
+	return value.Index, ok && value.Index > 0
+}
+
+// get returns the value of the key of a JSON object. The key is escaped,
+// so keys like "@type" aren't read as gjson path syntax.
+func get(obj gjson.Result, key string) gjson.Result {
+	return obj.Get(gjson.Escape(key))
+}
 
-	return result, nil
+func (r *JSONResolver) ResolveScalarValue(p graphql.ResolveParams) (interface{}, error) {
+	return r.lookup(p).Value(), nil
 }
 
 func (r *JSONResolver) ResolveObjectValue(p graphql.ResolveParams) (interface{}, error) {
-	var keyBuilder strings.Builder
-	for i, k := range p.Info.Path.AsArray() {
-		if i > 0 {
-			keyBuilder.WriteString(".")
+	data := r.lookup(p)
+	if !data.IsObject() {
+		return nil, nil
+	}
+
+	return data, nil
+}
+
+func (r *JSONResolver) ResolveArrayValue(p graphql.ResolveParams) (interface{}, error) {
+	data := r.lookup(p)
+	if !data.IsArray() {
+		return nil, nil
+	}
+
+	elements, err := limitElements(selectElements(data.Array(), p.Args), p.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	return listValue(elements), nil
+}
+
+// ResolveElements resolves the elements at the positions of the JSON array of the field, in the given order,
+// e.g. positions found in an index of the elements. The arguments apply as for ResolveArrayValue.
+func (r *JSONResolver) ResolveElements(p graphql.ResolveParams, positions []int) (interface{}, error) {
+	if _, ok := p.Source.(gjson.Result); !ok {
+		return r.resolveRootElements(p, positions)
+	}
+
+	data := r.lookup(p)
+	if !data.IsArray() {
+		return nil, nil
+	}
+
+	wanted := make(map[int]gjson.Result, len(positions))
+	for _, pos := range positions {
+		wanted[pos] = gjson.Result{}
+	}
+
+	i, found := 0, 0
+	data.ForEach(func(_, value gjson.Result) bool {
+		if _, ok := wanted[i]; ok {
+			wanted[i] = value
+			found++
+		}
+		i++
+
+		return found < len(wanted)
+	})
+
+	elements := make([]gjson.Result, 0, len(positions))
+	for _, pos := range positions {
+		if e := wanted[pos]; e.Exists() {
+			elements = append(elements, e)
 		}
-		keyBuilder.WriteString(fmt.Sprintf("%v", k))
 	}
-	key := keyBuilder.String()
 
-	data := gjson.Get(string(r.jsonData), key)
+	elements, err := limitElements(selectElements(elements, p.Args), p.Args)
+	if err != nil {
+		return nil, err
+	}
 
-	return data.Map(), nil
+	return listValue(elements), nil
 }
 
-func (r *JSONResolver) ResolveArrayValue(p graphql.ResolveParams) (interface{}, error) {
-	var key string
-	for i, k := range p.Info.Path.AsArray() {
-		if i > 0 {
-			key += "."
+// resolveRootElements picks the elements of a root array from the elements split once per document.
+func (r *JSONResolver) resolveRootElements(p graphql.ResolveParams, positions []int) (interface{}, error) {
+	doc := r.document(p)
+	if !doc.root[p.Info.FieldName].IsArray() {
+		return nil, nil
+	}
+
+	all := doc.elements(p.Info.FieldName)
+
+	elements := make([]gjson.Result, 0, len(positions))
+	for _, pos := range positions {
+		if pos >= 0 && pos < len(all) {
+			elements = append(elements, all[pos])
 		}
-		key += fmt.Sprintf("%v", k)
 	}
 
-	data := gjson.Get(string(r.jsonData), key)
+	elements, err := limitElements(selectElements(elements, p.Args), p.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	return listValue(elements), nil
+}
+
+// ResolveConnection resolves a Relay style connection over the JSON array of the field.
+func (r *JSONResolver) ResolveConnection(p graphql.ResolveParams) (interface{}, error) {
+	var elements []gjson.Result
+	if data := r.lookup(p); data.IsArray() {
+		elements = selectElements(data.Array(), p.Args)
+	}
+
+	start, end, err := connectionWindow(len(elements), p.Args)
+	if err != nil {
+		return nil, err
+	}
 
-	// fmt.Println("list resolved", key, data.Array())
+	edges := make([]interface{}, 0, end-start)
+	for i := start; i < end; i++ {
+		edges = append(edges, map[string]interface{}{
+			"node":   elements[i],
+			"cursor": encodeCursor(i),
+		})
+	}
+
+	pageInfo := map[string]interface{}{
+		"hasNextPage":     end < len(elements),
+		"hasPreviousPage": start > 0,
+	}
+
+	if end > start {
+		pageInfo["startCursor"] = encodeCursor(start)
+		pageInfo["endCursor"] = encodeCursor(end - 1)
+	}
+
+	return map[string]interface{}{
+		"edges":      edges,
+		"pageInfo":   pageInfo,
+		"totalCount": len(elements),
+	}, nil
+}
+
+// selectElements applies the "where" and "orderBy" arguments.
+func selectElements(elements []gjson.Result, args map[string]interface{}) []gjson.Result {
+	if where, ok := args["where"].(map[string]interface{}); ok {
+		elements = filter(elements, where)
+	}
+
+	if orderBy, ok := args["orderBy"].([]interface{}); ok {
+		sortElements(elements, orderBy)
+	}
+
+	return elements
+}
+
+// listValue converts elements to values graphql can complete:
+// objects stay gjson results resolved by their fields, nested arrays become slices
+// and scalars become plain values serialized by their scalar type.
+func listValue(elements []gjson.Result) []interface{} {
+	if elements == nil {
+		return nil
+	}
+
+	res := make([]interface{}, len(elements))
+	for i, e := range elements {
+		switch {
+		case e.IsObject():
+			res[i] = e
+		case e.IsArray():
+			res[i] = listValue(e.Array())
+		default:
+			res[i] = e.Value()
+		}
+	}
 
-	return data.Array(), nil
+	return res
 }
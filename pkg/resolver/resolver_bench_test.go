@@ -0,0 +1,212 @@
+package resolver_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/niklod/json-to-graphql-go/internal/builder"
+	"github.com/niklod/json-to-graphql-go/internal/field"
+	"github.com/niklod/json-to-graphql-go/pkg/resolver"
+	"github.com/niklod/json-to-graphql-go/pkg/snapshot"
+	"github.com/tidwall/gjson"
+)
+
+// largeFixture returns n users with an order each, padded by other top-level values.
+func largeFixture(n int) []byte {
+	var b bytes.Buffer
+	b.WriteString(`{"settings": {"theme": "dark"}, "users": [`)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, `{"id": %d, "name": "user %d", "address": {"city": "city %d", "zip": %d}, "tags": ["a", "b"]}`, i, i, i%100, i)
+	}
+	b.WriteString(`], "orders": [`)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, `{"id": %d, "userId": %d, "total": %d}`, i, (i*7)%n, i%50)
+	}
+	b.WriteString(`]}`)
+
+	return b.Bytes()
+}
+
+// benchmarkQuery runs the query against the resolver and, as the baseline, against the path resolver.
+func benchmarkQuery(b *testing.B, n int, query string) {
+	raw := largeFixture(n)
+
+	b.Run("resolver=document", func(b *testing.B) {
+		r := resolver.NewJSONResolver(raw)
+		benchmarkResolver(b, r, raw, r.Parse(raw), query)
+	})
+
+	b.Run("resolver=path", func(b *testing.B) {
+		benchmarkResolver(b, &pathResolver{data: raw}, raw, nil, query)
+	})
+}
+
+func benchmarkResolver(b *testing.B, r field.Resolver, raw []byte, document interface{}, query string) {
+	factory, err := field.NewDefaultFieldFactory(field.Config{Resolver: r, InferRelations: true})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var data map[string]interface{}
+	if err := decoder.Decode(&data); err != nil {
+		b.Fatal(err)
+	}
+
+	schema, err := builder.NewGraphQLSchemaBuilder(factory).BuildSchema(data)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	// Requests are pinned to a snapshot like the handler does.
+	ctx := snapshot.NewContext(context.Background(), &snapshot.Snapshot{Schema: schema, Data: raw, Document: document})
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		result := graphql.Do(graphql.Params{Schema: *schema, RequestString: query, Context: ctx})
+		if len(result.Errors) > 0 {
+			b.Fatal(result.Errors)
+		}
+	}
+}
+
+// pathResolver is the baseline of the benchmarks, the resolver before fields were resolved from their parent:
+// every field is read from the whole document along the path of its element, e.g. "users.9000.name".
+// List arguments are not supported.
+type pathResolver struct {
+	data []byte
+}
+
+// pathElement is a resolved object or array element together with its path in the document.
+type pathElement struct {
+	path  string
+	value gjson.Result
+}
+
+func (r *pathResolver) UpdateJsonData(jsonData []byte) {
+	r.data = jsonData
+}
+
+func (r *pathResolver) lookup(p graphql.ResolveParams) pathElement {
+	path := p.Info.FieldName
+	if parent, ok := p.Source.(pathElement); ok {
+		path = parent.path + "." + path
+	}
+
+	data := r.data
+	if s, ok := snapshot.FromContext(p.Context); ok {
+		data = s.Data
+	}
+
+	return pathElement{path: path, value: gjson.Get(string(data), path)}
+}
+
+func (r *pathResolver) ResolveScalarValue(p graphql.ResolveParams) (interface{}, error) {
+	return r.lookup(p).value.Value(), nil
+}
+
+func (r *pathResolver) ResolveObjectValue(p graphql.ResolveParams) (interface{}, error) {
+	if e := r.lookup(p); e.value.IsObject() {
+		return e, nil
+	}
+
+	return nil, nil
+}
+
+func (r *pathResolver) ResolveArrayValue(p graphql.ResolveParams) (interface{}, error) {
+	e := r.lookup(p)
+	if !e.value.IsArray() {
+		return nil, nil
+	}
+
+	return pathList(e, nil), nil
+}
+
+func (r *pathResolver) ResolveElements(p graphql.ResolveParams, positions []int) (interface{}, error) {
+	e := r.lookup(p)
+	if !e.value.IsArray() {
+		return nil, nil
+	}
+
+	return pathList(e, positions), nil
+}
+
+func (r *pathResolver) ResolveConnection(p graphql.ResolveParams) (interface{}, error) {
+	return nil, nil
+}
+
+func (r *pathResolver) Lookup(source interface{}, key string) interface{} {
+	if e, ok := source.(pathElement); ok {
+		return e.value.Get(key).Value()
+	}
+
+	return nil
+}
+
+func (r *pathResolver) Offset(source interface{}) (int, bool) {
+	e, ok := source.(pathElement)
+
+	return e.value.Index, ok && e.value.Index > 0
+}
+
+// pathList returns the elements of the array at the positions, all of them when positions is nil.
+func pathList(array pathElement, positions []int) []interface{} {
+	values := array.value.Array()
+	if positions == nil {
+		for i := range values {
+			positions = append(positions, i)
+		}
+	}
+
+	res := make([]interface{}, 0, len(positions))
+	for _, pos := range positions {
+		if pos < 0 || pos >= len(values) {
+			continue
+		}
+
+		e := pathElement{path: array.path + "." + strconv.Itoa(pos), value: values[pos]}
+		switch {
+		case e.value.IsObject():
+			res = append(res, e)
+		case e.value.IsArray():
+			res = append(res, pathList(e, nil))
+		default:
+			res = append(res, e.value.Value())
+		}
+	}
+
+	return res
+}
+
+// BenchmarkList selects nested fields of every element of a large list.
+func BenchmarkList(b *testing.B) {
+	benchmarkQuery(b, 2000, `{ users { id name address { city } tags } }`)
+}
+
+// BenchmarkRootFields resolves many small root fields of a large document.
+func BenchmarkRootFields(b *testing.B) {
+	benchmarkQuery(b, 10000, `{ a: settings { theme } b: settings { theme } c: settings { theme } d: settings { theme } }`)
+}
+
+// BenchmarkLookups looks up many objects by id.
+func BenchmarkLookups(b *testing.B) {
+	benchmarkQuery(b, 10000, `{ a: user(id: 9000) { name } b: user(id: 9500) { name } c: user(id: 9999) { name } }`)
+}
+
+// BenchmarkRelations follows a relation from every element of a large list.
+func BenchmarkRelations(b *testing.B) {
+	benchmarkQuery(b, 2000, `{ orders { id user { name } } }`)
+}
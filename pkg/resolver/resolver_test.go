@@ -0,0 +1,230 @@
+package resolver_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/niklod/json-to-graphql-go/pkg/resolver"
+	"github.com/niklod/json-to-graphql-go/pkg/snapshot"
+	"github.com/stretchr/testify/assert"
+)
+
+const resolverTestData = `{
+    "shop": {"name": "Acme", "address": {"city": "Berlin"}},
+    "tags": ["a", "b", ["c"]],
+    "users": [
+        {"id": 1, "name": "Alice", "age": 30},
+        {"id": 2, "name": "Bob", "age": 25},
+        {"id": 3, "name": "Carol", "age": 35}
+    ],
+    "odd keys": {"@type": "Person", "a.b": 1, "#": 2}
+}`
+
+func params(ctx context.Context, source interface{}, fieldName string, args map[string]interface{}) graphql.ResolveParams {
+	return graphql.ResolveParams{
+		Context: ctx,
+		Source:  source,
+		Args:    args,
+		Info:    graphql.ResolveInfo{FieldName: fieldName},
+	}
+}
+
+// scalar resolves the scalar field of the parent value.
+func scalar(t *testing.T, r *resolver.JSONResolver, parent interface{}, name string) interface{} {
+	t.Helper()
+
+	value, err := r.ResolveScalarValue(params(context.Background(), parent, name, nil))
+	assert.NoError(t, err)
+
+	return value
+}
+
+// names resolves the "name" field of every element of a list.
+func names(t *testing.T, r *resolver.JSONResolver, list interface{}) []interface{} {
+	t.Helper()
+
+	res := []interface{}{}
+	for _, e := range list.([]interface{}) {
+		res = append(res, scalar(t, r, e, "name"))
+	}
+
+	return res
+}
+
+// TestResolveValues verifies that fields resolve from their parent value and root fields from the document.
+func TestResolveValues(t *testing.T) {
+	r := resolver.NewJSONResolver([]byte(resolverTestData))
+	ctx := context.Background()
+
+	shop, err := r.ResolveObjectValue(params(ctx, nil, "shop", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "Acme", scalar(t, r, shop, "name"))
+
+	address, err := r.ResolveObjectValue(params(ctx, shop, "address", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "Berlin", scalar(t, r, address, "city"))
+
+	missing, err := r.ResolveObjectValue(params(ctx, shop, "owner", nil))
+	assert.NoError(t, err)
+	assert.Nil(t, missing, "Missing objects should resolve to nil")
+
+	notObject, err := r.ResolveObjectValue(params(ctx, nil, "tags", nil))
+	assert.NoError(t, err)
+	assert.Nil(t, notObject, "Arrays should not resolve as objects")
+
+	tags, err := r.ResolveArrayValue(params(ctx, nil, "tags", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"a", "b", []interface{}{"c"}}, tags, "Scalars and nested arrays should be plain values")
+
+	assert.Nil(t, scalar(t, r, nil, "missing"), "Missing fields should resolve to nil")
+	assert.Equal(t, "Acme", r.Lookup(shop, "name"))
+	assert.Nil(t, r.Lookup(map[string]interface{}{"name": "Acme"}, "name"), "Only resolved values should be looked up")
+}
+
+// TestResolveOffsets verifies that resolved objects are identified by their offset in the data.
+func TestResolveOffsets(t *testing.T) {
+	r := resolver.NewJSONResolver([]byte(resolverTestData))
+
+	users, err := r.ResolveArrayValue(params(context.Background(), nil, "users", nil))
+	assert.NoError(t, err)
+
+	picked, err := r.ResolveElements(params(context.Background(), nil, "users", nil), []int{1})
+	assert.NoError(t, err)
+
+	first, ok := r.Offset(users.([]interface{})[0])
+	assert.True(t, ok)
+
+	second, ok := r.Offset(users.([]interface{})[1])
+	assert.True(t, ok)
+	assert.NotEqual(t, first, second)
+
+	offset, _ := r.Offset(picked.([]interface{})[0])
+	assert.Equal(t, second, offset, "The same object should have the same offset however it is resolved")
+
+	_, ok = r.Offset(map[string]interface{}{"name": "Acme"})
+	assert.False(t, ok, "Only resolved values have an offset")
+}
+
+// TestResolveKeysLiterally verifies that keys with gjson path syntax are read as plain keys.
+func TestResolveKeysLiterally(t *testing.T) {
+	r := resolver.NewJSONResolver([]byte(resolverTestData))
+
+	obj, err := r.ResolveObjectValue(params(context.Background(), nil, "odd keys", nil))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Person", scalar(t, r, obj, "@type"))
+	assert.Equal(t, float64(1), scalar(t, r, obj, "a.b"))
+	assert.Equal(t, float64(2), scalar(t, r, obj, "#"))
+}
+
+// TestResolveListArguments verifies where, orderBy, offset and limit on list fields.
+func TestResolveListArguments(t *testing.T) {
+	r := resolver.NewJSONResolver([]byte(resolverTestData))
+
+	users, err := r.ResolveArrayValue(params(context.Background(), nil, "users", map[string]interface{}{
+		"where":   map[string]interface{}{"age": map[string]interface{}{"gte": 30}},
+		"orderBy": []interface{}{map[string]interface{}{"field": "name", "direction": "DESC"}},
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"Carol", "Alice"}, names(t, r, users))
+
+	users, err = r.ResolveArrayValue(params(context.Background(), nil, "users", map[string]interface{}{
+		"orderBy": []interface{}{map[string]interface{}{"field": "age"}},
+		"offset":  1,
+		"limit":   1,
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"Alice"}, names(t, r, users))
+
+	users, err = r.ResolveArrayValue(params(context.Background(), nil, "users", map[string]interface{}{
+		"where": map[string]interface{}{"id": map[string]interface{}{"in": []interface{}{"1", "3"}}},
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"Alice", "Carol"}, names(t, r, users), "ID arguments should match numeric ids")
+
+	_, err = r.ResolveArrayValue(params(context.Background(), nil, "users", map[string]interface{}{"limit": -1}))
+	assert.Error(t, err, "Negative limits should error")
+}
+
+// TestResolveElements verifies that elements are picked by position, in the given order.
+func TestResolveElements(t *testing.T) {
+	r := resolver.NewJSONResolver([]byte(resolverTestData))
+
+	users, err := r.ResolveElements(params(context.Background(), nil, "users", nil), []int{2, 0, 7})
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"Carol", "Alice"}, names(t, r, users), "Positions out of range should be left out")
+
+	users, err = r.ResolveElements(params(context.Background(), nil, "users", map[string]interface{}{
+		"orderBy": []interface{}{map[string]interface{}{"field": "name"}},
+	}), []int{2, 1})
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"Bob", "Carol"}, names(t, r, users), "Arguments should apply to the picked elements")
+
+	shop, err := r.ResolveObjectValue(params(context.Background(), nil, "shop", nil))
+	assert.NoError(t, err)
+
+	none, err := r.ResolveElements(params(context.Background(), shop, "name", nil), []int{0})
+	assert.NoError(t, err)
+	assert.Nil(t, none, "Non-array fields should resolve to nil")
+}
+
+// TestResolveConnection verifies the edges, cursors and page info of connections.
+func TestResolveConnection(t *testing.T) {
+	r := resolver.NewJSONResolver([]byte(resolverTestData))
+
+	res, err := r.ResolveConnection(params(context.Background(), nil, "users", map[string]interface{}{"first": 2}))
+	assert.NoError(t, err)
+
+	connection := res.(map[string]interface{})
+	assert.Equal(t, 3, connection["totalCount"])
+
+	edges := connection["edges"].([]interface{})
+	assert.Len(t, edges, 2)
+	assert.Equal(t, "Alice", scalar(t, r, edges[0].(map[string]interface{})["node"], "name"))
+
+	pageInfo := connection["pageInfo"].(map[string]interface{})
+	assert.Equal(t, true, pageInfo["hasNextPage"])
+	assert.Equal(t, false, pageInfo["hasPreviousPage"])
+
+	res, err = r.ResolveConnection(params(context.Background(), nil, "users", map[string]interface{}{
+		"after": pageInfo["endCursor"],
+	}))
+	assert.NoError(t, err)
+
+	edges = res.(map[string]interface{})["edges"].([]interface{})
+	assert.Len(t, edges, 1)
+	assert.Equal(t, "Carol", scalar(t, r, edges[0].(map[string]interface{})["node"], "name"))
+
+	_, err = r.ResolveConnection(params(context.Background(), nil, "users", map[string]interface{}{"after": "nope"}))
+	assert.ErrorIs(t, err, resolver.ErrInvalidCursor)
+}
+
+// TestResolvePinnedSnapshot verifies that requests resolve against the data of the snapshot they are pinned to.
+func TestResolvePinnedSnapshot(t *testing.T) {
+	oldData := []byte(`{"shop": {"name": "Old"}}`)
+	newData := []byte(`{"shop": {"name": "New"}}`)
+	otherData := []byte(`{"shop": {"name": "Other"}}`)
+
+	r := resolver.NewJSONResolver(oldData)
+	oldDocument := r.Parse(oldData)
+
+	r.UpdateJsonData(newData)
+	assert.Same(t, r.Parse(newData), r.Parse(newData), "The current data should not be parsed again")
+
+	resolveName := func(ctx context.Context) interface{} {
+		shop, err := r.ResolveObjectValue(params(ctx, nil, "shop", nil))
+		assert.NoError(t, err)
+
+		return scalar(t, r, shop, "name")
+	}
+
+	assert.Equal(t, "New", resolveName(context.Background()), "Unpinned requests should read the current data")
+	assert.Equal(t, "Old", resolveName(snapshot.NewContext(context.Background(), &snapshot.Snapshot{
+		Data: oldData, Document: oldDocument,
+	})))
+	assert.Equal(t, "Old", resolveName(snapshot.NewContext(context.Background(), &snapshot.Snapshot{Data: oldData})),
+		"Snapshots without a document should be parsed for the request")
+	assert.Equal(t, "New", resolveName(snapshot.NewContext(context.Background(), &snapshot.Snapshot{Data: newData})))
+	assert.Equal(t, "Other", resolveName(snapshot.NewContext(context.Background(), &snapshot.Snapshot{Data: otherData})))
+}
@@ -0,0 +1,55 @@
+package snapshot
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/graphql-go/graphql"
+)
+
+// Snapshot is an immutable schema together with the data it was built from.
+// A request is served from one snapshot from start to finish,
+// so it never sees a new schema with old data or the other way around.
+type Snapshot struct {
+	Schema   *graphql.Schema
+	Data     []byte
+	Version  string
+	LoadedAt time.Time
+	// Document is the data as parsed by the resolver when the snapshot is built,
+	// nil when the resolver parses the data of the request itself.
+	Document interface{}
+}
+
+type contextKey struct{}
+
+// NewContext returns a context carrying the snapshot.
+func NewContext(ctx context.Context, s *Snapshot) context.Context {
+	return context.WithValue(ctx, contextKey{}, s)
+}
+
+// FromContext returns the snapshot the request is pinned to.
+func FromContext(ctx context.Context) (*Snapshot, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+
+	s, ok := ctx.Value(contextKey{}).(*Snapshot)
+
+	return s, ok && s != nil
+}
+
+// Store holds the current snapshot and swaps it atomically.
+type Store struct {
+	current atomic.Pointer[Snapshot]
+}
+
+// Load returns the current snapshot or nil if none was stored yet.
+func (s *Store) Load() *Snapshot {
+	return s.current.Load()
+}
+
+// Store replaces the current snapshot.
+func (s *Store) Store(snapshot *Snapshot) {
+	s.current.Store(snapshot)
+}
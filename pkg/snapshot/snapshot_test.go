@@ -0,0 +1,56 @@
+package snapshot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestContext verifies that a request context carries the snapshot it is pinned to.
+func TestContext(t *testing.T) {
+	s := &Snapshot{Data: []byte(`{}`), Version: "v1"}
+
+	ctx := NewContext(context.Background(), s)
+	got, ok := FromContext(ctx)
+	assert.True(t, ok, "Pinned context should carry the snapshot")
+	assert.Same(t, s, got)
+
+	// A nested pin replaces the outer one.
+	inner := &Snapshot{Version: "v2"}
+	got, ok = FromContext(NewContext(ctx, inner))
+	assert.True(t, ok)
+	assert.Same(t, inner, got)
+
+	// The outer context is unchanged.
+	got, _ = FromContext(ctx)
+	assert.Same(t, s, got)
+}
+
+// TestContextWithoutSnapshot verifies that unpinned, nil and nil snapshot contexts carry no snapshot.
+func TestContextWithoutSnapshot(t *testing.T) {
+	_, ok := FromContext(context.Background())
+	assert.False(t, ok, "Unpinned context should carry no snapshot")
+
+	// Resolvers called outside of a request may have no context.
+	_, ok = FromContext(nil)
+	assert.False(t, ok, "Nil context should carry no snapshot")
+
+	_, ok = FromContext(NewContext(context.Background(), nil))
+	assert.False(t, ok, "Nil snapshot should not be reported")
+}
+
+// TestStore verifies that the store returns the last stored snapshot.
+func TestStore(t *testing.T) {
+	var store Store
+	assert.Nil(t, store.Load(), "Empty store should have no snapshot")
+
+	first := &Snapshot{Version: "v1"}
+	store.Store(first)
+	assert.Same(t, first, store.Load())
+
+	second := &Snapshot{Version: "v2"}
+	store.Store(second)
+	assert.Same(t, second, store.Load())
+	assert.Equal(t, "v1", first.Version, "Replaced snapshots stay intact")
+}
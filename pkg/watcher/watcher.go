@@ -0,0 +1,44 @@
+package watcher
+
+import (
+	"errors"
+	"io"
+	"sync"
+)
+
+var ErrUnsupported = errors.New("file system events are not supported on this platform")
+
+// Watcher reports changes of files inside a set of directories.
+//
+// Directories are watched instead of files so that editors which save
+// through a temporary file and a rename are still noticed.
+type Watcher struct {
+	events chan string
+	closer io.Closer
+	once   sync.Once
+}
+
+// Events returns the paths of changed files accepted by the match function.
+// The channel is closed when the watcher stops.
+func (w *Watcher) Events() <-chan string {
+	return w.events
+}
+
+// Close stops the watcher.
+func (w *Watcher) Close() error {
+	var err error
+	w.once.Do(func() {
+		err = w.closer.Close()
+	})
+
+	return err
+}
+
+// notify sends the path without blocking the reader.
+// Dropping an event is fine because consumers only need to know that something changed.
+func (w *Watcher) notify(path string) {
+	select {
+	case w.events <- path:
+	default:
+	}
+}
@@ -0,0 +1,82 @@
+//go:build linux
+
+package watcher
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+const watchMask = syscall.IN_CLOSE_WRITE |
+	syscall.IN_CREATE |
+	syscall.IN_DELETE |
+	syscall.IN_MODIFY |
+	syscall.IN_MOVED_FROM |
+	syscall.IN_MOVED_TO
+
+// New starts an inotify watcher on the given directories.
+// Only paths accepted by match are reported.
+func New(dirs []string, match func(path string) bool) (*Watcher, error) {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return nil, fmt.Errorf("inotify init: %w", err)
+	}
+
+	// The descriptor is non-blocking, so the file is served by the runtime poller
+	// and Close unblocks a pending Read.
+	file := os.NewFile(uintptr(fd), "inotify")
+
+	watched := make(map[int32]string, len(dirs))
+	for _, dir := range dirs {
+		wd, err := syscall.InotifyAddWatch(fd, dir, watchMask)
+		if err != nil {
+			file.Close()
+
+			return nil, fmt.Errorf("watch %s: %w", dir, err)
+		}
+
+		watched[int32(wd)] = dir
+	}
+
+	w := &Watcher{
+		events: make(chan string, 16),
+		closer: file,
+	}
+
+	go w.readEvents(file, watched, match)
+
+	return w, nil
+}
+
+func (w *Watcher) readEvents(file *os.File, watched map[int32]string, match func(path string) bool) {
+	defer close(w.events)
+
+	buf := make([]byte, 64*(syscall.SizeofInotifyEvent+syscall.NAME_MAX+1))
+	for {
+		n, err := file.Read(buf)
+		if err != nil {
+			return
+		}
+
+		for offset := 0; offset+syscall.SizeofInotifyEvent <= n; {
+			wd := int32(binary.NativeEndian.Uint32(buf[offset:]))
+			nameLen := int(binary.NativeEndian.Uint32(buf[offset+12:]))
+			nameStart := offset + syscall.SizeofInotifyEvent
+			name := string(bytes.TrimRight(buf[nameStart:nameStart+nameLen], "\x00"))
+			offset = nameStart + nameLen
+
+			dir, ok := watched[wd]
+			if !ok || name == "" {
+				continue
+			}
+
+			if path := filepath.Join(dir, name); match(path) {
+				w.notify(path)
+			}
+		}
+	}
+}
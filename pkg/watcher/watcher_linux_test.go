@@ -0,0 +1,52 @@
+//go:build linux
+
+package watcher
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func waitEvent(t *testing.T, w *Watcher) (string, bool) {
+	t.Helper()
+
+	select {
+	case path, ok := <-w.Events():
+		return path, ok
+	case <-time.After(2 * time.Second):
+		return "", false
+	}
+}
+
+// TestRenameIsReported verifies that saving through a temp file and a rename is noticed.
+func TestRenameIsReported(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "data.json")
+
+	w, err := New([]string{dir}, func(path string) bool { return path == target })
+	assert.NoError(t, err, "Watcher creation should not error")
+	defer w.Close()
+
+	tmp := filepath.Join(dir, ".data.json.swp")
+	assert.NoError(t, os.WriteFile(tmp, []byte(`{}`), 0o644))
+	assert.NoError(t, os.Rename(tmp, target))
+
+	path, ok := waitEvent(t, w)
+	assert.True(t, ok, "An event should be reported")
+	assert.Equal(t, target, path, "Only the matched file should be reported")
+}
+
+// TestCloseStopsWatcher verifies that Close closes the events channel.
+func TestCloseStopsWatcher(t *testing.T) {
+	w, err := New([]string{t.TempDir()}, func(string) bool { return true })
+	assert.NoError(t, err, "Watcher creation should not error")
+
+	assert.NoError(t, w.Close())
+
+	_, ok := waitEvent(t, w)
+	assert.False(t, ok, "Events channel should be closed")
+}
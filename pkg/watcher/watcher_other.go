@@ -0,0 +1,8 @@
+//go:build !linux
+
+package watcher
+
+// New is not implemented on this platform, callers should fall back to polling.
+func New(dirs []string, match func(path string) bool) (*Watcher, error) {
+	return nil, ErrUnsupported
+}